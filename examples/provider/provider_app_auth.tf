@@ -0,0 +1,11 @@
+provider "github" {
+  owner = "craigsloggett-lab" # Or the GITHUB_OWNER environment variable.
+
+  # Or the GITHUB_APP_ID, GITHUB_APP_INSTALLATION_ID and
+  # GITHUB_APP_PRIVATE_KEY_FILE environment variables.
+  app_auth {
+    id               = var.github_app_id
+    installation_id  = var.github_app_installation_id
+    private_key_file = var.github_app_private_key_file
+  }
+}
@@ -0,0 +1,4 @@
+provider "github" {
+  base_url = "https://github.example.com/" # Or the GITHUB_BASE_URL environment variable.
+  token    = var.github_token              # Or the GITHUB_TOKEN environment variable.
+}
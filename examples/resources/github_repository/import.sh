@@ -3,3 +3,9 @@
 # Repositories can be imported using the numerical
 # GitHub ID of the repository.
 terraform import github_repository.test 123456789
+
+# Repositories can also be imported using the owner and
+# name of the repository, or only the name when the
+# repository is owned by the provider configured owner.
+terraform import github_repository.test craigsloggett-lab/terraform-aws-module
+terraform import github_repository.test terraform-aws-module
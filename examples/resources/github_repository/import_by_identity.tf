@@ -0,0 +1,7 @@
+import {
+  to = github_repository.example
+  identity = {
+    owner = "craigsloggett-lab"
+    name  = "terraform-aws-module"
+  }
+}
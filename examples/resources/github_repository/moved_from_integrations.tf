@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    github = {
+      source = "craigsloggett/github"
+    }
+    # The integrations/github provider is only needed until the move has been
+    # applied.
+    integrations = {
+      source = "integrations/github"
+    }
+  }
+}
+
+moved {
+  from = github_repository.terraform_aws_module
+  to   = github_repository.example
+}
+
+resource "github_repository" "example" {
+  name = "terraform-aws-module"
+}
@@ -0,0 +1,7 @@
+resource "github_repository" "example" {
+  name = "terraform-aws-module"
+
+  # Archive the repository rather than deleting it when it is removed from the
+  # configuration.
+  archive_on_destroy = true
+}
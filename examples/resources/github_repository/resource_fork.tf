@@ -0,0 +1,9 @@
+resource "github_repository" "example" {
+  name = "terraform-provider-github"
+
+  fork {
+    source_owner        = "integrations"
+    source_repo         = "terraform-provider-github"
+    default_branch_only = true
+  }
+}
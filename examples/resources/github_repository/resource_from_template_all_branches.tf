@@ -0,0 +1,10 @@
+resource "github_repository" "example" {
+  name = "terraform-aws-module"
+
+  template_repository  = "terraform-module-template"
+  include_all_branches = true
+
+  timeouts {
+    create = "20m"
+  }
+}
@@ -0,0 +1,6 @@
+resource "github_repository" "example" {
+  # Manage a repository in another organization than the one configured in
+  # the provider.
+  owner = "craigsloggett-lab"
+  name  = "terraform-aws-module"
+}
@@ -0,0 +1,8 @@
+resource "github_repository" "example" {
+  name = "terraform-aws-module"
+
+  # Rename the existing default branch (for example `master`) rather than
+  # switching to an existing branch.
+  default_branch        = "main"
+  rename_default_branch = true
+}
@@ -0,0 +1,13 @@
+resource "github_repository" "example" {
+  name       = "terraform-aws-module"
+  visibility = "public"
+
+  security_and_analysis {
+    secret_scanning {
+      status = "enabled"
+    }
+    secret_scanning_push_protection {
+      status = "enabled"
+    }
+  }
+}
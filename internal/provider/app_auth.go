@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v84/github"
+)
+
+const (
+	// GitHub rejects app JWTs that expire more than 10 minutes in the future.
+	appJWTLifetime = 9 * time.Minute
+
+	// Issue JWTs slightly in the past to allow for clock drift.
+	appJWTClockSkew = 60 * time.Second
+
+	// Refresh installation tokens this long before they expire so that
+	// requests in flight never carry an expired token.
+	installationTokenRefreshWindow = 5 * time.Minute
+)
+
+// parseAppPrivateKey decodes a PEM encoded RSA private key as downloaded from
+// the GitHub App settings page (PKCS#1), or converted to PKCS#8.
+func parseAppPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found in private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an RSA private key, got: %T", parsed)
+	}
+
+	return key, nil
+}
+
+// signAppJWT creates an RS256 signed JSON Web Token used to authenticate as
+// a GitHub App.
+func signAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// appJWTTransport authenticates every request as the GitHub App itself. It is
+// only used to exchange the JWT for an installation access token.
+type appJWTTransport struct {
+	appID int64
+	key   *rsa.PrivateKey
+	base  http.RoundTripper
+}
+
+func (t *appJWTTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	jwt, err := signAppJWT(t.appID, t.key, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign GitHub App JWT: %w", err)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	return t.base.RoundTrip(req)
+}
+
+// appInstallationTransport authenticates every request as a GitHub App
+// installation. The installation access token is cached and transparently
+// refreshed shortly before it expires, so long running applies keep working.
+type appInstallationTransport struct {
+	installationID int64
+	apps           *github.Client
+	base           http.RoundTripper
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// newAppInstallationTransport returns a transport that authenticates as the
+// given app installation, sending requests through base. Installation tokens
+// are requested from the API at baseURL (see newGitHubClient).
+func newAppInstallationTransport(base http.RoundTripper, baseURL string, appID, installationID int64, key *rsa.PrivateKey) (*appInstallationTransport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	apps, err := newGitHubClient(&http.Client{
+		Transport: &appJWTTransport{appID: appID, key: key, base: base},
+	}, baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rate limits are handled by the transport beneath this one.
+	apps.DisableRateLimitCheck = true
+
+	return &appInstallationTransport{
+		installationID: installationID,
+		apps:           apps,
+		base:           base,
+	}, nil
+}
+
+// Token returns a valid installation access token, requesting a new one from
+// the GitHub API when the cached token is missing or about to expire.
+func (t *appInstallationTransport) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Until(t.expiresAt) > installationTokenRefreshWindow {
+		return t.token, nil
+	}
+
+	token, _, err := t.apps.Apps.CreateInstallationToken(ctx, t.installationID, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to create installation token: %w", err)
+	}
+
+	t.token = token.GetToken()
+	t.expiresAt = token.GetExpiresAt().Time
+
+	return t.token, nil
+}
+
+func (t *appInstallationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseAppPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range map[string][]byte{
+		"pkcs1": pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		"pkcs8": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseAppPrivateKey(data)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !parsed.Equal(key) {
+				t.Fatal("parsed key does not match the original key")
+			}
+		})
+	}
+
+	if _, err := parseAppPrivateKey([]byte("not a key")); err == nil {
+		t.Fatal("expected an error parsing invalid PEM data")
+	}
+}
+
+func TestAppInstallationTransport(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tokensIssued atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/app/installations/42/access_tokens":
+			if r.Method != http.MethodPost {
+				t.Errorf("expected POST, got: %s", r.Method)
+			}
+			jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if strings.Count(jwt, ".") != 2 {
+				t.Errorf("expected a JWT, got: %q", jwt)
+			}
+			n := tokensIssued.Add(1)
+			expiresAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token": "ghs_%d", "expires_at": %q}`, n, expiresAt)
+		default:
+			fmt.Fprintf(w, `{"authorization": %q}`, r.Header.Get("Authorization"))
+		}
+	}))
+	defer server.Close()
+
+	transport, err := newAppInstallationTransport(nil, server.URL, 1, 42, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &http.Client{Transport: transport}
+
+	get := func() string {
+		t.Helper()
+		resp, err := client.Get(server.URL + "/repos/octocat/hello-world")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	if got := get(); !strings.Contains(got, "ghs_1") {
+		t.Fatalf("expected the first installation token, got: %s", got)
+	}
+
+	// A cached token well within its lifetime is reused.
+	if got := get(); !strings.Contains(got, "ghs_1") || tokensIssued.Load() != 1 {
+		t.Fatalf("expected the cached installation token, got: %s", got)
+	}
+
+	// A token inside the refresh window is replaced before it is used.
+	transport.expiresAt = time.Now().Add(time.Minute)
+	if got := get(); !strings.Contains(got, "ghs_2") {
+		t.Fatalf("expected a refreshed installation token, got: %s", got)
+	}
+}
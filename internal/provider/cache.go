@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// cachedResponse is a successful GET response stored along with the
+// validators (ETag, Last-Modified) used to revalidate it.
+type cachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// responseCache stores responses in memory and, when a directory is set, on
+// disk so they can be reused across Terraform runs.
+//
+// A response is only returned to the credentials it was fetched with, since
+// what GitHub returns depends on who is asking. The directory may be shared by
+// providers configured with different credentials, so each identity, such as a
+// token or an app installation, stores its entries in its own subdirectory.
+type responseCache struct {
+	directory string
+
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+func newResponseCache(directory, identity string) (*responseCache, error) {
+	if directory != "" {
+		// The identity may be a token, only its hash is written to disk.
+		sum := sha256.Sum256([]byte(identity))
+		directory = filepath.Join(directory, hex.EncodeToString(sum[:]))
+
+		if err := os.MkdirAll(directory, 0o700); err != nil {
+			return nil, err
+		}
+	}
+
+	return &responseCache{
+		directory: directory,
+		entries:   make(map[string]*cachedResponse),
+	}, nil
+}
+
+// cacheKey identifies a response by its URL and the media type requested,
+// since the same URL can return different representations.
+func cacheKey(req *http.Request) string {
+	return req.URL.String() + " " + req.Header.Get("Accept")
+}
+
+func (c *responseCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.directory, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *responseCache) get(key string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		return entry
+	}
+
+	if c.directory == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil
+	}
+
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+
+	c.entries[key] = &entry
+
+	return &entry
+}
+
+// set stores an entry in memory and, best effort, on disk. A failure to write
+// to disk only means the entry will not survive the current run.
+func (c *responseCache) set(key string, entry *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+
+	if c.directory == "" {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	// Write to a temporary file first so concurrent readers never see a
+	// partially written entry.
+	tmp, err := os.CreateTemp(c.directory, ".entry-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return
+	}
+
+	_ = os.Rename(tmp.Name(), c.path(key))
+}
+
+// cacheTransport makes conditional GET requests for responses it has seen
+// before. GitHub answers with 304 Not Modified when nothing has changed, which
+// does not count against the rate limit, and the cached response is returned
+// in its place.
+type cacheTransport struct {
+	base  http.RoundTripper
+	cache *responseCache
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	entry := t.cache.get(key)
+
+	if entry != nil {
+		req = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry != nil && resp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		return entry.response(req, resp), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || (resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.cache.set(key, &cachedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+	})
+
+	return resp, nil
+}
+
+// response rebuilds the cached response for req. Headers describing the
+// current state of the rate limit are taken from the 304 response.
+func (e *cachedResponse) response(req *http.Request, notModified *http.Response) *http.Response {
+	header := e.Header.Clone()
+
+	for name, values := range notModified.Header {
+		if strings.HasPrefix(name, "X-Ratelimit-") {
+			header[name] = values
+		}
+	}
+
+	header.Set("X-From-Cache", "1")
+	header.Set("Content-Length", strconv.Itoa(len(e.Body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCacheTransport(t *testing.T) {
+	var requests, notModified atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(5000-requests.Load()))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"name": "hello-world"}`)
+	}))
+	defer server.Close()
+
+	directory := t.TempDir()
+
+	get := func(transport http.RoundTripper) *http.Response {
+		t.Helper()
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL + "/repositories/1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	read := func(resp *http.Response) string {
+		t.Helper()
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	cache, err := newResponseCache(directory, "token:one")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &cacheTransport{base: http.DefaultTransport, cache: cache}
+
+	if body := read(get(transport)); body != `{"name": "hello-world"}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+
+	resp := get(transport)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-From-Cache") == "" {
+		t.Errorf("expected a cached 200 response, got: %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("X-RateLimit-Remaining"); got != "4998" {
+		t.Errorf("expected rate limit headers from the 304 response, got: %s", got)
+	}
+	if body := read(resp); body != `{"name": "hello-world"}` {
+		t.Fatalf("unexpected cached body: %s", body)
+	}
+
+	// A new cache backed by the same directory revalidates the stored entry.
+	cache, err = newResponseCache(directory, "token:one")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport = &cacheTransport{base: http.DefaultTransport, cache: cache}
+
+	if body := read(get(transport)); body != `{"name": "hello-world"}` {
+		t.Fatalf("unexpected body from disk: %s", body)
+	}
+
+	if notModified.Load() != 2 {
+		t.Errorf("expected 2 conditional requests to be answered with 304, got: %d", notModified.Load())
+	}
+
+	// Entries stored for other credentials are not used.
+	cache, err = newResponseCache(directory, "token:two")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport = &cacheTransport{base: http.DefaultTransport, cache: cache}
+
+	resp = get(transport)
+	if resp.Header.Get("X-From-Cache") != "" {
+		t.Errorf("expected an uncached response for other credentials")
+	}
+	read(resp)
+
+	if notModified.Load() != 2 {
+		t.Errorf("expected no conditional request for other credentials, got: %d", notModified.Load()-2)
+	}
+}
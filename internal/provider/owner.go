@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v84/github"
+	"golang.org/x/sync/singleflight"
+)
+
+// resolvedOwner is a GitHub user or organization as returned by the API.
+type resolvedOwner struct {
+	// Login is the canonical login of the owner.
+	Login string
+
+	// Organization is the login of the owner when it is an organization, and
+	// empty when it is a user.
+	Organization string
+}
+
+// ownerCache resolves owners to their canonical login and type, and looks up
+// the settings of organizations. Lookups are cached for the lifetime of the
+// provider instance so that resources targeting the same owner only look it up
+// once.
+//
+// The lock only guards the maps, it is not held while waiting on the GitHub
+// API. Concurrent lookups of the same owner share a single request, and
+// lookups of different owners do not wait on each other.
+type ownerCache struct {
+	client *github.Client
+
+	mu            sync.Mutex
+	owners        map[string]resolvedOwner
+	organizations map[string]*github.Organization
+
+	ownerLookups        singleflight.Group
+	organizationLookups singleflight.Group
+}
+
+func newOwnerCache(client *github.Client) *ownerCache {
+	return &ownerCache{
+		client:        client,
+		owners:        make(map[string]resolvedOwner),
+		organizations: make(map[string]*github.Organization),
+	}
+}
+
+// Resolve looks up owner, which may be a user or an organization. An empty
+// owner resolves to the authenticated user.
+func (c *ownerCache) Resolve(ctx context.Context, owner string) (resolvedOwner, error) {
+	// Logins are case-insensitive.
+	key := strings.ToLower(owner)
+
+	c.mu.Lock()
+	resolved, ok := c.owners[key]
+	c.mu.Unlock()
+
+	if ok {
+		return resolved, nil
+	}
+
+	value, err, _ := c.ownerLookups.Do(key, func() (any, error) {
+		user, _, err := c.client.Users.Get(ctx, owner)
+		if err != nil {
+			return resolvedOwner{}, err
+		}
+
+		resolved := resolvedOwner{Login: user.GetLogin()}
+		if user.GetType() == "Organization" {
+			resolved.Organization = user.GetLogin()
+		}
+
+		c.mu.Lock()
+		c.owners[key] = resolved
+		c.owners[strings.ToLower(resolved.Login)] = resolved
+		c.mu.Unlock()
+
+		return resolved, nil
+	})
+	if err != nil {
+		return resolvedOwner{}, err
+	}
+
+	return value.(resolvedOwner), nil
+}
+
+// Organization looks up the settings of organization.
+func (c *ownerCache) Organization(ctx context.Context, organization string) (*github.Organization, error) {
+	// Logins are case-insensitive.
+	key := strings.ToLower(organization)
+
+	c.mu.Lock()
+	org, ok := c.organizations[key]
+	c.mu.Unlock()
+
+	if ok {
+		return org, nil
+	}
+
+	value, err, _ := c.organizationLookups.Do(key, func() (any, error) {
+		org, _, err := c.client.Organizations.Get(ctx, organization)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.organizations[key] = org
+		c.mu.Unlock()
+
+		return org, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*github.Organization), nil
+}
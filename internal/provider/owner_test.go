@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOwnerCacheResolve(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/api/v3/user":
+			fmt.Fprint(w, `{"login": "octocat", "type": "User"}`)
+		case "/api/v3/users/github":
+			fmt.Fprint(w, `{"login": "GitHub", "type": "Organization"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not Found"}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := newGitHubClient(nil, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owners := newOwnerCache(client)
+	ctx := context.Background()
+
+	user, err := owners.Resolve(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Login != "octocat" || user.Organization != "" {
+		t.Fatalf("expected the authenticated user, got: %+v", user)
+	}
+
+	org, err := owners.Resolve(ctx, "github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if org.Login != "GitHub" || org.Organization != "GitHub" {
+		t.Fatalf("expected an organization with its canonical login, got: %+v", org)
+	}
+
+	// Lookups are cached, including by the canonical login.
+	for _, owner := range []string{"", "github", "GitHub", "octocat"} {
+		if _, err := owners.Resolve(ctx, owner); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("expected 2 requests, got: %d", got)
+	}
+
+	// Failed lookups are not cached.
+	if _, err := owners.Resolve(ctx, "missing"); err == nil {
+		t.Fatal("expected an error resolving a missing owner")
+	}
+	if _, err := owners.Resolve(ctx, "missing"); err == nil {
+		t.Fatal("expected an error resolving a missing owner")
+	}
+	if got := requests.Load(); got != 4 {
+		t.Fatalf("expected 4 requests, got: %d", got)
+	}
+}
+
+func TestOwnerCacheOrganization(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/api/v3/orgs/github":
+			fmt.Fprint(w, `{"login": "GitHub", "members_can_fork_private_repositories": false}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not Found"}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := newGitHubClient(nil, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owners := newOwnerCache(client)
+	ctx := context.Background()
+
+	for _, organization := range []string{"github", "GitHub"} {
+		org, err := owners.Organization(ctx, organization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if org.GetLogin() != "GitHub" || org.GetMembersCanForkPrivateRepos() {
+			t.Fatalf("expected the organization settings, got: %+v", org)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected 1 request, got: %d", got)
+	}
+
+	// Failed lookups are not cached.
+	for range 2 {
+		if _, err := owners.Organization(ctx, "missing"); err == nil {
+			t.Fatal("expected an error looking up a missing organization")
+		}
+	}
+	if got := requests.Load(); got != 3 {
+		t.Fatalf("expected 3 requests, got: %d", got)
+	}
+}
+
+func TestOwnerCacheConcurrentResolve(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/api/v3/users/slow":
+			<-release
+			fmt.Fprint(w, `{"login": "slow", "type": "User"}`)
+		case "/api/v3/users/github":
+			fmt.Fprint(w, `{"login": "GitHub", "type": "Organization"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not Found"}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := newGitHubClient(nil, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owners := newOwnerCache(client)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Go(func() {
+			if _, err := owners.Resolve(ctx, "slow"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	// Other owners are resolved while the slow lookup is still waiting.
+	if _, err := owners.Resolve(ctx, "github"); err != nil {
+		t.Fatal(err)
+	}
+
+	close(release)
+	wg.Wait()
+
+	// Concurrent lookups of the same owner share a request, but a lookup that
+	// starts after the first has finished may make another.
+	if got := requests.Load(); got < 2 || got > 4 {
+		t.Fatalf("expected between 2 and 4 requests, got: %d", got)
+	}
+}
@@ -2,17 +2,28 @@ package provider
 
 import (
 	"context"
+	"crypto/rsa"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/craigsloggett/terraform-provider-github/internal/functions"
 
 	"github.com/google/go-github/v84/github"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,14 +32,33 @@ var _ provider.Provider = &GitHubProvider{}
 type GitHubProvider struct{}
 
 type GitHubProviderModel struct {
-	Owner types.String `tfsdk:"owner"`
-	Token types.String `tfsdk:"token"`
+	Owner           types.String  `tfsdk:"owner"`
+	Token           types.String  `tfsdk:"token"`
+	BaseURL         types.String  `tfsdk:"base_url"`
+	MaxRetries      types.Int64   `tfsdk:"max_retries"`
+	RetryableErrors types.List    `tfsdk:"retryable_errors"`
+	Cache           types.Bool    `tfsdk:"cache"`
+	CacheDirectory  types.String  `tfsdk:"cache_directory"`
+	ReadOnly        types.Bool    `tfsdk:"read_only"`
+	AppAuth         *appAuthModel `tfsdk:"app_auth"`
+}
+
+type appAuthModel struct {
+	ID             types.Int64  `tfsdk:"id"`
+	InstallationID types.Int64  `tfsdk:"installation_id"`
+	PrivateKey     types.String `tfsdk:"private_key"`
+	PrivateKeyFile types.String `tfsdk:"private_key_file"`
 }
 
 type GitHubClientConfiguration struct {
 	Client       *github.Client
 	Owner        string
 	Organization string
+	ReadOnly     bool
+
+	// Owners resolves owners other than the configured one, for resources
+	// that set their own owner.
+	Owners *ownerCache
 }
 
 func NewGitHubProvider() func() provider.Provider {
@@ -48,9 +78,73 @@ func (p *GitHubProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				MarkdownDescription: "The target GitHub organization or individual user account to manage. Alternatively, can be configured using the `GITHUB_OWNER` environment variable.",
 				Optional:            true,
 			},
+			"base_url": schema.StringAttribute{
+				MarkdownDescription: "The base URL of a GitHub Enterprise Server instance to manage, e.g. `https://github.example.com/`. The `/api/v3/` path is added automatically when omitted. For GitHub Enterprise Cloud with data residency, use the `ghe.com` URL of the enterprise, e.g. `https://octocorp.ghe.com/`. Defaults to the public GitHub API. Alternatively, can be configured using the `GITHUB_BASE_URL` environment variable.",
+				Optional:            true,
+			},
+			"cache": schema.BoolAttribute{
+				MarkdownDescription: "Cache GET responses in memory and revalidate them with conditional requests (`If-None-Match`). Unchanged resources are answered with `304 Not Modified`, which does not count against the rate limit. Defaults to `false`.",
+				Optional:            true,
+			},
+			"cache_directory": schema.StringAttribute{
+				MarkdownDescription: "A directory in which cached responses are also stored, so they can be reused across Terraform runs. Setting this enables the `cache`. Responses are stored in a subdirectory for each token or GitHub App installation, so the directory can be shared. The directory contains API responses and should be treated as sensitive.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of times a request is retried after hitting a rate limit or failing with one of the `retryable_errors`. Rate limited requests wait for the time requested by GitHub, other failures back off exponentially. Set to `0` to disable retries. Defaults to `3`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Prevent the provider from making any changes. Resources that would be created, updated, or deleted fail at plan time, and any request other than a read is rejected by the client. Alternatively, can be configured using the `GITHUB_READ_ONLY` environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
+			"retryable_errors": schema.ListAttribute{
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "The HTTP status codes of responses that are retried for `GET`, `HEAD`, `PUT` and `DELETE` requests. Other requests are not retried, since GitHub may have applied them before failing. `403` and `429` responses are only retried when they are rate limited, so they cannot be set. Defaults to `[500, 502, 503, 504]`.",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(
+						int64validator.Between(400, 599),
+						int64validator.NoneOf(http.StatusForbidden, http.StatusTooManyRequests),
+					),
+				},
+			},
 			"token": schema.StringAttribute{
 				MarkdownDescription: "The GitHub fine-grained personal access token used to authenticate with the API. Alternatively, can be configured using the `GITHUB_TOKEN` environment variable.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("app_auth")),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"app_auth": schema.SingleNestedBlock{
+				MarkdownDescription: "Authenticate as a GitHub App installation instead of using a personal access token. Installation tokens are requested on demand and refreshed before they expire. The `owner` must be configured when using this block.",
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Int64Attribute{
+						MarkdownDescription: "The ID of the GitHub App. Alternatively, can be configured using the `GITHUB_APP_ID` environment variable.",
+						Optional:            true,
+					},
+					"installation_id": schema.Int64Attribute{
+						MarkdownDescription: "The ID of the GitHub App installation. Alternatively, can be configured using the `GITHUB_APP_INSTALLATION_ID` environment variable.",
+						Optional:            true,
+					},
+					"private_key": schema.StringAttribute{
+						MarkdownDescription: "The PEM encoded private key of the GitHub App. Alternatively, can be configured using the `GITHUB_APP_PRIVATE_KEY` environment variable.",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("private_key_file")),
+						},
+					},
+					"private_key_file": schema.StringAttribute{
+						MarkdownDescription: "The path to a file containing the PEM encoded private key of the GitHub App. Alternatively, can be configured using the `GITHUB_APP_PRIVATE_KEY_FILE` environment variable.",
+						Optional:            true,
+					},
+				},
 			},
 		},
 	}
@@ -62,6 +156,8 @@ func (p *GitHubProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	owner := os.Getenv("GITHUB_OWNER")
 	token := os.Getenv("GITHUB_TOKEN")
+	baseURL := os.Getenv("GITHUB_BASE_URL")
+	readOnly, _ := strconv.ParseBool(os.Getenv("GITHUB_READ_ONLY"))
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
 
@@ -74,7 +170,62 @@ func (p *GitHubProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		token = model.Token.ValueString()
 	}
 
-	if token == "" {
+	// Prioritize an owner configured in the provider over the GITHUB_OWNER environment variable.
+	if model.Owner.ValueString() != "" {
+		owner = model.Owner.ValueString()
+	}
+
+	// Prioritize a base URL configured in the provider over the GITHUB_BASE_URL environment variable.
+	if model.BaseURL.ValueString() != "" {
+		baseURL = model.BaseURL.ValueString()
+	}
+
+	// Prioritize read_only configured in the provider over the GITHUB_READ_ONLY environment variable.
+	if !model.ReadOnly.IsNull() {
+		readOnly = model.ReadOnly.ValueBool()
+	}
+
+	maxRetries := int64(defaultMaxRetries)
+	retryableErrors := defaultRetryableErrors
+
+	if !model.MaxRetries.IsNull() {
+		maxRetries = model.MaxRetries.ValueInt64()
+	}
+
+	if !model.RetryableErrors.IsNull() {
+		resp.Diagnostics.Append(model.RetryableErrors.ElementsAs(ctx, &retryableErrors, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The credentials are resolved first, the response cache is kept apart for
+	// each of them.
+	var appAuth *appAuthSettings
+	cacheIdentity := "token:" + token
+
+	if model.AppAuth != nil {
+		// An installation token cannot look up the authenticated user, so the
+		// owner has to be known up front.
+		if owner == "" {
+			resp.Diagnostics.AddError(
+				"Missing Owner Configuration",
+				"While configuring the provider with app_auth, an owner was not found in "+
+					"the GITHUB_OWNER environment variable or provider configuration "+
+					"block owner attribute.",
+			)
+			return
+		}
+
+		var diags diag.Diagnostics
+		appAuth, diags = resolveAppAuth(model.AppAuth)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		cacheIdentity = fmt.Sprintf("app:%d/installation:%d", appAuth.appID, appAuth.installationID)
+	} else if token == "" {
 		resp.Diagnostics.AddError(
 			"Missing Personal Access Token Configuration",
 			"While configuring the provider, a GitHub token was not found in "+
@@ -84,16 +235,72 @@ func (p *GitHubProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	client := github.NewClient(nil).WithAuthToken(token)
+	// Every request, including those made to refresh GitHub App installation
+	// tokens, goes through the retry transport.
+	var transport http.RoundTripper = newRetryTransport(http.DefaultTransport, int(maxRetries), retryableErrors)
 
-	// Prioritize an owner configured in the provider over the GITHUB_OWNER environment variable.
-	if model.Owner.ValueString() != "" {
-		owner = model.Owner.ValueString()
+	if model.Cache.ValueBool() || model.CacheDirectory.ValueString() != "" {
+		cache, err := newResponseCache(model.CacheDirectory.ValueString(), cacheIdentity)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cache_directory"),
+				"Invalid Cache Directory Configuration",
+				fmt.Sprintf("Unable to create the cache directory, got error: %s", err),
+			)
+			return
+		}
+
+		// Responses are cached for the lifetime of the provider and shared by
+		// every resource and data source through the configured client.
+		transport = &cacheTransport{base: transport, cache: cache}
+	}
+
+	// The transport used by the client shared with every resource and data
+	// source. GitHub App authentication adds its own layer on top.
+	clientTransport := transport
+
+	if appAuth != nil {
+		appTransport, err := newAppInstallationTransport(transport, baseURL, appAuth.appID, appAuth.installationID, appAuth.key)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("base_url"),
+				"Invalid Base URL Configuration",
+				fmt.Sprintf("Unable to configure the GitHub API base URL, got error: %s", err),
+			)
+			return
+		}
+
+		clientTransport = appTransport
+	}
+
+	// Only the shared client is read-only, installation tokens are still
+	// requested with a POST.
+	if readOnly {
+		clientTransport = &readOnlyTransport{base: clientTransport}
+	}
+
+	client, err := newGitHubClient(&http.Client{Transport: clientTransport}, baseURL)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_url"),
+			"Invalid Base URL Configuration",
+			fmt.Sprintf("Unable to configure the GitHub API base URL, got error: %s", err),
+		)
+		return
+	}
+
+	if model.AppAuth == nil {
+		client = client.WithAuthToken(token)
 	}
 
+	// Rate limits are handled by the retry transport, which needs to see the
+	// responses rather than have the client reject requests pre-emptively.
+	client.DisableRateLimitCheck = true
+
 	// Fetch the user or organization based on the configured owner.
 	// If owner is empty, GitHub will return the authenticated user.
-	user, _, err := client.Users.Get(ctx, owner)
+	owners := newOwnerCache(client)
+	resolved, err := owners.Resolve(ctx, owner)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -104,22 +311,171 @@ func (p *GitHubProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	// Use what the GitHub API returns as the canonical owner string.
-	owner = user.GetLogin()
-
-	if user.GetType() == "Organization" {
-		organization = owner
-	}
+	owner = resolved.Login
+	organization = resolved.Organization
 
 	config := &GitHubClientConfiguration{
 		Client:       client,
 		Owner:        owner,
 		Organization: organization,
+		ReadOnly:     readOnly,
+		Owners:       owners,
 	}
 
 	resp.DataSourceData = config
 	resp.ResourceData = config
 }
 
+// newGitHubClient returns a GitHub API client sending requests through
+// httpClient. When baseURL is set, the client targets that GitHub Enterprise
+// Server instance instead of the public GitHub API. The base URL may be given
+// with or without the `/api/v3/` path. GitHub Enterprise Cloud with data
+// residency serves the API from the `api.` subdomain of its `ghe.com` host.
+func newGitHubClient(httpClient *http.Client, baseURL string) (*github.Client, error) {
+	client := github.NewClient(httpClient)
+
+	if baseURL == "" {
+		return client, nil
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("expected an absolute URL, got: %q", baseURL)
+	}
+
+	// The public GitHub API does not need any enterprise configuration.
+	if u.Host == "github.com" || u.Host == "api.github.com" {
+		return client, nil
+	}
+
+	// GitHub Enterprise Cloud with data residency has no `/api/v3/` path.
+	if tenant := strings.TrimPrefix(u.Hostname(), "api."); strings.HasSuffix(tenant, ".ghe.com") {
+		return client.WithEnterpriseURLs(u.Scheme+"://api."+tenant+"/", u.Scheme+"://uploads."+tenant+"/")
+	}
+
+	// Normalise to the root of the instance so the API and upload paths are
+	// both derived from the same host.
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/api/v3")
+	root := u.String()
+
+	return client.WithEnterpriseURLs(root, root)
+}
+
+// appAuthSettings are the resolved settings used to authenticate as a GitHub
+// App installation.
+type appAuthSettings struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+}
+
+// resolveAppAuth resolves the settings used to authenticate as a GitHub App
+// installation. Each argument in the app_auth block takes priority over its
+// corresponding environment variable.
+func resolveAppAuth(model *appAuthModel) (*appAuthSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	appID := model.ID.ValueInt64()
+	installationID := model.InstallationID.ValueInt64()
+	privateKey := model.PrivateKey.ValueString()
+	privateKeyFile := model.PrivateKeyFile.ValueString()
+
+	if appID == 0 && os.Getenv("GITHUB_APP_ID") != "" {
+		id, err := strconv.ParseInt(os.Getenv("GITHUB_APP_ID"), 10, 64)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("app_auth").AtName("id"),
+				"Invalid GitHub App ID",
+				fmt.Sprintf("The GITHUB_APP_ID environment variable should be an integer, got error: %s", err),
+			)
+		}
+		appID = id
+	}
+
+	if installationID == 0 && os.Getenv("GITHUB_APP_INSTALLATION_ID") != "" {
+		id, err := strconv.ParseInt(os.Getenv("GITHUB_APP_INSTALLATION_ID"), 10, 64)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("app_auth").AtName("installation_id"),
+				"Invalid GitHub App Installation ID",
+				fmt.Sprintf("The GITHUB_APP_INSTALLATION_ID environment variable should be an integer, got error: %s", err),
+			)
+		}
+		installationID = id
+	}
+
+	if privateKey == "" && privateKeyFile == "" {
+		privateKey = os.Getenv("GITHUB_APP_PRIVATE_KEY")
+		privateKeyFile = os.Getenv("GITHUB_APP_PRIVATE_KEY_FILE")
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if appID == 0 {
+		diags.AddAttributeError(
+			path.Root("app_auth").AtName("id"),
+			"Missing GitHub App ID Configuration",
+			"While configuring the provider, a GitHub App ID was not found in "+
+				"the GITHUB_APP_ID environment variable or app_auth block id attribute.",
+		)
+	}
+
+	if installationID == 0 {
+		diags.AddAttributeError(
+			path.Root("app_auth").AtName("installation_id"),
+			"Missing GitHub App Installation ID Configuration",
+			"While configuring the provider, a GitHub App installation ID was not found in "+
+				"the GITHUB_APP_INSTALLATION_ID environment variable or app_auth block installation_id attribute.",
+		)
+	}
+
+	// Prefer the private key contents over a path to the private key.
+	if privateKey == "" && privateKeyFile != "" {
+		data, err := os.ReadFile(privateKeyFile)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("app_auth").AtName("private_key_file"),
+				"Unable to Read GitHub App Private Key",
+				fmt.Sprintf("Unable to read the private key file, got error: %s", err),
+			)
+			return nil, diags
+		}
+		privateKey = string(data)
+	}
+
+	if privateKey == "" {
+		diags.AddAttributeError(
+			path.Root("app_auth").AtName("private_key"),
+			"Missing GitHub App Private Key Configuration",
+			"While configuring the provider, a GitHub App private key was not found in "+
+				"the GITHUB_APP_PRIVATE_KEY or GITHUB_APP_PRIVATE_KEY_FILE environment variables "+
+				"or app_auth block private_key or private_key_file attributes.",
+		)
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	key, err := parseAppPrivateKey([]byte(privateKey))
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("app_auth").AtName("private_key"),
+			"Invalid GitHub App Private Key",
+			fmt.Sprintf("Unable to parse the private key, got error: %s", err),
+		)
+		return nil, diags
+	}
+
+	return &appAuthSettings{appID: appID, installationID: installationID, key: key}, diags
+}
+
 func (p *GitHubProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewGitHubRepositoryDataSource,
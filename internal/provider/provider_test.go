@@ -1,8 +1,11 @@
 package provider
 
 import (
+	"context"
+	"os"
 	"testing"
 
+	"github.com/google/go-github/v84/github"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -19,8 +22,72 @@ terraform {
 provider "github" {}
 `
 
+const providerReadOnlyConfig = `
+terraform {
+  required_providers {
+    github = {
+      source  = "craigsloggett/github"
+    }
+  }
+}
+
+provider "github" {
+  read_only = true
+}
+`
+
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"github": providerserver.NewProtocol6WithError(NewGitHubProvider()()),
 }
 
 func testAccPreCheck(t *testing.T) {}
+
+// testAccClient returns a GitHub API client and the owner used by the
+// acceptance tests, for making changes outside of Terraform.
+func testAccClient(t *testing.T) (*github.Client, string) {
+	t.Helper()
+
+	client := github.NewClient(nil).WithAuthToken(os.Getenv("GITHUB_TOKEN"))
+
+	user, _, err := client.Users.Get(context.Background(), os.Getenv("GITHUB_OWNER"))
+	if err != nil {
+		t.Fatalf("unable to get user: %s", err)
+	}
+
+	return client, user.GetLogin()
+}
+
+func TestNewGitHubClientBaseURL(t *testing.T) {
+	for baseURL, expected := range map[string]string{
+		"":                                   "https://api.github.com/",
+		"https://api.github.com/":            "https://api.github.com/",
+		"https://github.example.com":         "https://github.example.com/api/v3/",
+		"https://github.example.com/":        "https://github.example.com/api/v3/",
+		"https://github.example.com/api/v3":  "https://github.example.com/api/v3/",
+		"https://github.example.com/api/v3/": "https://github.example.com/api/v3/",
+		"https://example.com/github/api/v3/": "https://example.com/github/api/v3/",
+		"https://api.example.ghe.com/":       "https://api.example.ghe.com/",
+		"https://example.ghe.com":            "https://api.example.ghe.com/",
+		"https://example.ghe.com/":           "https://api.example.ghe.com/",
+	} {
+		client, err := newGitHubClient(nil, baseURL)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", baseURL, err)
+		}
+		if got := client.BaseURL.String(); got != expected {
+			t.Errorf("expected base URL %q for %q, got: %q", expected, baseURL, got)
+		}
+	}
+
+	client, err := newGitHubClient(nil, "https://example.ghe.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := client.UploadURL.String(); got != "https://uploads.example.ghe.com/" {
+		t.Errorf("expected the uploads subdomain for a ghe.com host, got: %q", got)
+	}
+
+	if _, err := newGitHubClient(nil, "github.example.com"); err == nil {
+		t.Error("expected an error for a relative base URL")
+	}
+}
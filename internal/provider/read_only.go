@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// readOnlyTransport rejects every request that could change something in
+// GitHub when the provider is configured with read_only.
+type readOnlyTransport struct {
+	base http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		// The request body is owned by the transport once RoundTrip is called.
+		if req.Body != nil {
+			req.Body.Close()
+		}
+
+		return nil, fmt.Errorf("the provider is configured as read_only, refusing to send %s request", req.Method)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// modifyPlanReadOnly fails the plan of any resource that would be created,
+// updated, or deleted while the provider is configured as read_only. Every
+// resource should call it from ModifyPlan so read-only runs fail at plan time
+// rather than part way through an apply, after making any changes of its own
+// to the plan.
+func modifyPlanReadOnly(readOnly bool, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !readOnly {
+		return
+	}
+
+	var action string
+
+	switch {
+	case req.State.Raw.IsNull():
+		action = "created"
+	case resp.Plan.Raw.IsNull():
+		action = "deleted"
+	case !resp.Plan.Raw.Equal(req.State.Raw):
+		action = "updated"
+	default:
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Provider is Read-Only",
+		fmt.Sprintf("This resource would be %s, but the provider is configured as read_only. "+
+			"Remove the change from the configuration or disable read_only on the provider.", action),
+	)
+}
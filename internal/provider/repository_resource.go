@@ -2,27 +2,51 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/v84/github"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &GitHubRepositoryResource{}
 var _ resource.ResourceWithImportState = &GitHubRepositoryResource{}
+var _ resource.ResourceWithModifyPlan = &GitHubRepositoryResource{}
+var _ resource.ResourceWithValidateConfig = &GitHubRepositoryResource{}
+var _ resource.ResourceWithIdentity = &GitHubRepositoryResource{}
+var _ resource.ResourceWithMoveState = &GitHubRepositoryResource{}
+var _ resource.ResourceWithUpgradeState = &GitHubRepositoryResource{}
 
 // Types
 
@@ -30,19 +54,30 @@ type GitHubRepositoryResource struct {
 	client       *github.Client
 	owner        string
 	organization string
+	readOnly     bool
+	owners       *ownerCache
 }
 
 type GitHubRepositoryResourceModel struct {
 	// Arguments
+	Owner                    types.String `tfsdk:"owner"`
+	TransferTeamIDs          types.Set    `tfsdk:"transfer_team_ids"`
 	Name                     types.String `tfsdk:"name"`
 	Description              types.String `tfsdk:"description"`
 	Homepage                 types.String `tfsdk:"homepage"`
 	Private                  types.Bool   `tfsdk:"private"`
+	Visibility               types.String `tfsdk:"visibility"`
 	HasIssues                types.Bool   `tfsdk:"has_issues"`
 	HasProjects              types.Bool   `tfsdk:"has_projects"`
 	HasWiki                  types.Bool   `tfsdk:"has_wiki"`
 	HasDiscussions           types.Bool   `tfsdk:"has_discussions"`
-	Topics                   types.List   `tfsdk:"topics"`
+	HasDownloads             types.Bool   `tfsdk:"has_downloads"`
+	HasPages                 types.Bool   `tfsdk:"has_pages"`
+	AllowForking             types.Bool   `tfsdk:"allow_forking"`
+	WebCommitSignoffRequired types.Bool   `tfsdk:"web_commit_signoff_required"`
+	Topics                   types.Set    `tfsdk:"topics"`
+	DefaultBranch            types.String `tfsdk:"default_branch"`
+	RenameDefaultBranch      types.Bool   `tfsdk:"rename_default_branch"`
 	AutoInit                 types.Bool   `tfsdk:"auto_init"`
 	GitignoreTemplate        types.String `tfsdk:"gitignore_template"`
 	LicenseTemplate          types.String `tfsdk:"license_template"`
@@ -57,14 +92,47 @@ type GitHubRepositoryResourceModel struct {
 	MergeCommitTitle         types.String `tfsdk:"merge_commit_title"`
 	MergeCommitMessage       types.String `tfsdk:"merge_commit_message"`
 	IsTemplate               types.Bool   `tfsdk:"is_template"`
+	Archived                 types.Bool   `tfsdk:"archived"`
+	ArchiveOnDestroy         types.Bool   `tfsdk:"archive_on_destroy"`
+	DeletionProtection       types.Bool   `tfsdk:"deletion_protection"`
+
+	// Security Arguments
+	VulnerabilityAlerts           types.Bool `tfsdk:"vulnerability_alerts"`
+	AutomatedSecurityFixes        types.Bool `tfsdk:"automated_security_fixes"`
+	PrivateVulnerabilityReporting types.Bool `tfsdk:"private_vulnerability_reporting"`
+
+	// Blocks
+	Fork                *repositoryForkModel      `tfsdk:"fork"`
+	SecurityAndAnalysis *securityAndAnalysisModel `tfsdk:"security_and_analysis"`
+	Timeouts            timeouts.Value            `tfsdk:"timeouts"`
 
 	// Template Arguments
 	TemplateRepository types.String `tfsdk:"template_repository"`
 	TemplateOwner      types.String `tfsdk:"template_owner"`
+	IncludeAllBranches types.Bool   `tfsdk:"include_all_branches"`
 
 	// Attributes
-	ID     types.Int64  `tfsdk:"id"`
-	NodeID types.String `tfsdk:"node_id"`
+	ID       types.Int64  `tfsdk:"id"`
+	NodeID   types.String `tfsdk:"node_id"`
+	FullName types.String `tfsdk:"full_name"`
+	HTMLURL  types.String `tfsdk:"html_url"`
+	CloneURL types.String `tfsdk:"clone_url"`
+	GitURL   types.String `tfsdk:"git_url"`
+	SSHURL   types.String `tfsdk:"ssh_url"`
+	SVNURL   types.String `tfsdk:"svn_url"`
+	URL      types.String `tfsdk:"url"`
+}
+
+type repositoryForkModel struct {
+	SourceOwner       types.String `tfsdk:"source_owner"`
+	SourceRepo        types.String `tfsdk:"source_repo"`
+	DefaultBranchOnly types.Bool   `tfsdk:"default_branch_only"`
+}
+
+type GitHubRepositoryResourceIdentityModel struct {
+	Owner types.String `tfsdk:"owner"`
+	Name  types.String `tfsdk:"name"`
+	ID    types.Int64  `tfsdk:"id"`
 }
 
 // Constructor
@@ -75,6 +143,18 @@ func NewGitHubRepositoryResource() resource.Resource {
 
 // Helpers
 
+const (
+	// Transfers, forks and repositories created from a template are completed
+	// asynchronously by GitHub, and are polled until the operation times out.
+	repositoryPollInterval = 2 * time.Second
+
+	// Default timeouts, which can be overridden with the timeouts block.
+	repositoryCreateTimeout = 10 * time.Minute
+	repositoryReadTimeout   = 5 * time.Minute
+	repositoryUpdateTimeout = 10 * time.Minute
+	repositoryDeleteTimeout = 5 * time.Minute
+)
+
 type expansionMode int
 
 const (
@@ -90,7 +170,6 @@ func expandRepository(model GitHubRepositoryResourceModel, mode expansionMode) *
 		Name:                new(model.Name.ValueString()),
 		Description:         new(model.Description.ValueString()),
 		Homepage:            new(model.Homepage.ValueString()),
-		Private:             new(model.Private.ValueBool()),
 		HasIssues:           new(model.HasIssues.ValueBool()),
 		HasProjects:         new(model.HasProjects.ValueBool()),
 		HasWiki:             new(model.HasWiki.ValueBool()),
@@ -104,6 +183,32 @@ func expandRepository(model GitHubRepositoryResourceModel, mode expansionMode) *
 		IsTemplate:          new(model.IsTemplate.ValueBool()),
 	}
 
+	// Settings without a default are left to GitHub when not set.
+	if !model.HasDownloads.IsNull() && !model.HasDownloads.IsUnknown() {
+		repo.HasDownloads = new(model.HasDownloads.ValueBool())
+	}
+	if !model.WebCommitSignoffRequired.IsNull() && !model.WebCommitSignoffRequired.IsUnknown() {
+		repo.WebCommitSignoffRequired = new(model.WebCommitSignoffRequired.ValueBool())
+	}
+
+	// Organizations can forbid forking private repositories, in which case
+	// GitHub rejects any value for allow_forking. Only send it when set.
+	if !model.AllowForking.IsNull() && !model.AllowForking.IsUnknown() {
+		repo.AllowForking = new(model.AllowForking.ValueBool())
+	}
+
+	// Visibility is the only way to make a repository internal. Private is
+	// reconciled with it at plan time (see modifyPlanVisibility) and sent
+	// alongside it, since some endpoints, such as creating a repository for
+	// the authenticated user, only accept private. Neither is sent when not
+	// set, so that a fork keeps the visibility of the repository it forked.
+	if !model.Private.IsNull() && !model.Private.IsUnknown() {
+		repo.Private = new(model.Private.ValueBool())
+	}
+	if !model.Visibility.IsNull() && !model.Visibility.IsUnknown() {
+		repo.Visibility = new(model.Visibility.ValueString())
+	}
+
 	// Create is the only time you can successfully pass these parameters into the GitHub API.
 	if mode == expandForCreate {
 		repo.AutoInit = new(model.AutoInit.ValueBool())
@@ -111,6 +216,11 @@ func expandRepository(model GitHubRepositoryResourceModel, mode expansionMode) *
 		repo.LicenseTemplate = new(model.LicenseTemplate.ValueString())
 	}
 
+	// Security and analysis settings can only be changed on an existing repository.
+	if mode == expandForUpdate {
+		repo.SecurityAndAnalysis = expandSecurityAndAnalysis(model.SecurityAndAnalysis)
+	}
+
 	if model.AllowSquashMerge.ValueBool() {
 		repo.SquashMergeCommitTitle = new(model.SquashMergeCommitTitle.ValueString())
 		repo.SquashMergeCommitMessage = new(model.SquashMergeCommitMessage.ValueString())
@@ -136,11 +246,17 @@ func flattenRepository(ctx context.Context, model *GitHubRepositoryResourceModel
 	model.Description = types.StringValue(repo.GetDescription())
 	model.Homepage = types.StringValue(repo.GetHomepage())
 	model.Private = types.BoolValue(repo.GetPrivate())
+	model.Visibility = types.StringValue(repo.GetVisibility())
 	model.HasIssues = types.BoolValue(repo.GetHasIssues())
 	model.HasProjects = types.BoolValue(repo.GetHasProjects())
 	model.HasWiki = types.BoolValue(repo.GetHasWiki())
 	model.HasDiscussions = types.BoolValue(repo.GetHasDiscussions())
-	model.Topics, _ = types.ListValueFrom(ctx, types.StringType, repo.Topics)
+	model.HasDownloads = types.BoolValue(repo.GetHasDownloads())
+	model.HasPages = types.BoolValue(repo.GetHasPages())
+	model.AllowForking = types.BoolValue(repo.GetAllowForking())
+	model.WebCommitSignoffRequired = types.BoolValue(repo.GetWebCommitSignoffRequired())
+	model.Topics, _ = types.SetValueFrom(ctx, types.StringType, repo.Topics)
+	model.DefaultBranch = types.StringValue(repo.GetDefaultBranch())
 	model.AllowSquashMerge = types.BoolValue(repo.GetAllowSquashMerge())
 	model.AllowMergeCommit = types.BoolValue(repo.GetAllowMergeCommit())
 	model.AllowRebaseMerge = types.BoolValue(repo.GetAllowRebaseMerge())
@@ -152,22 +268,750 @@ func flattenRepository(ctx context.Context, model *GitHubRepositoryResourceModel
 	model.MergeCommitTitle = types.StringValue(repo.GetMergeCommitTitle())
 	model.MergeCommitMessage = types.StringValue(repo.GetMergeCommitMessage())
 	model.IsTemplate = types.BoolValue(repo.GetIsTemplate())
+	model.Archived = types.BoolValue(repo.GetArchived())
+	// Attributes
+	model.FullName = types.StringValue(repo.GetFullName())
+	model.HTMLURL = types.StringValue(repo.GetHTMLURL())
+	model.CloneURL = types.StringValue(repo.GetCloneURL())
+	model.GitURL = types.StringValue(repo.GetGitURL())
+	model.SSHURL = types.StringValue(repo.GetSSHURL())
+	model.SVNURL = types.StringValue(repo.GetSVNURL())
+	model.URL = types.StringValue(repo.GetURL())
+	// Blocks
+	flattenSecurityAndAnalysis(model.SecurityAndAnalysis, repo.GetSecurityAndAnalysis())
+}
+
+// flattenRepositoryFork reads the repository that a fork was created from when
+// it is tracked in state, as it is once the fork has been created or imported.
+// GitHub does not report whether only the default branch was forked, so
+// default_branch_only is kept from state.
+func flattenRepositoryFork(model *GitHubRepositoryResourceModel, repo *github.Repository) {
+	if model.Fork == nil {
+		return
+	}
+
+	if !repo.GetFork() {
+		model.Fork = nil
+		return
+	}
+
+	// The parent is not reported once it has been deleted.
+	parent := repo.GetParent()
+	if parent == nil {
+		return
+	}
+
+	// Logins and names are case-insensitive, keep the configured case.
+	if !strings.EqualFold(model.Fork.SourceOwner.ValueString(), parent.GetOwner().GetLogin()) ||
+		!strings.EqualFold(model.Fork.SourceRepo.ValueString(), parent.GetName()) {
+		model.Fork.SourceOwner = types.StringValue(parent.GetOwner().GetLogin())
+		model.Fork.SourceRepo = types.StringValue(parent.GetName())
+	}
+}
+
+// flattenRepositoryTemplate reads the template a repository was created from
+// when it is tracked in state, as it is once the repository has been created or
+// imported. GitHub does not report whether all branches of the template were
+// included, so include_all_branches is kept from state.
+func flattenRepositoryTemplate(model *GitHubRepositoryResourceModel, repo *github.Repository) {
+	// The template is not reported once it has been deleted.
+	template := repo.GetTemplateRepository()
+	if template == nil || model.TemplateRepository.IsNull() {
+		return
+	}
+
+	// Logins and names are case-insensitive, keep the configured case.
+	if !strings.EqualFold(model.TemplateOwner.ValueString(), template.GetOwner().GetLogin()) ||
+		!strings.EqualFold(model.TemplateRepository.ValueString(), template.GetName()) {
+		model.TemplateOwner = types.StringValue(template.GetOwner().GetLogin())
+		model.TemplateRepository = types.StringValue(template.GetName())
+	}
+}
+
+// requiresReplaceIfNotEqualFold requires a new repository when a login or
+// repository name changes other than in case, since GitHub treats them as
+// case-insensitive. Removing the argument from the configuration only stops it
+// from being tracked.
+func requiresReplaceIfNotEqualFold(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull() ||
+		!strings.EqualFold(req.StateValue.ValueString(), req.PlanValue.ValueString())
+}
+
+// requiresReplaceIfKnown requires a new repository when an argument that is
+// not read from GitHub changes, unless it is not known in state, as for
+// imported repositories, or it is removed from the configuration.
+func requiresReplaceIfKnown(_ context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull() && !req.ConfigValue.IsNull()
+}
+
+// forkRequiresReplace requires a new repository when the repository it is
+// forked from changes. A default_branch_only that is not known in state, as
+// for imported forks, is not compared. Removing the block from the
+// configuration only stops the fork from being tracked.
+func forkRequiresReplace(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	if req.StateValue.IsNull() || req.PlanValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	var state, plan repositoryForkModel
+	resp.Diagnostics.Append(req.StateValue.As(ctx, &state, basetypes.ObjectAsOptions{})...)
+	resp.Diagnostics.Append(req.PlanValue.As(ctx, &plan, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.RequiresReplace = !strings.EqualFold(state.SourceOwner.ValueString(), plan.SourceOwner.ValueString()) ||
+		!strings.EqualFold(state.SourceRepo.ValueString(), plan.SourceRepo.ValueString()) ||
+		(!state.DefaultBranchOnly.IsNull() && !state.DefaultBranchOnly.Equal(plan.DefaultBranchOnly))
+}
+
+// unknownNameAttributes marks the computed attributes that are derived from
+// the name of the repository as unknown, since they change when the
+// repository is renamed and cannot be kept from state.
+func unknownNameAttributes(plan *GitHubRepositoryResourceModel) {
+	plan.FullName = types.StringUnknown()
+	plan.HTMLURL = types.StringUnknown()
+	plan.CloneURL = types.StringUnknown()
+	plan.GitURL = types.StringUnknown()
+	plan.SSHURL = types.StringUnknown()
+	plan.SVNURL = types.StringUnknown()
+	plan.URL = types.StringUnknown()
+}
+
+// expandSecurityAndAnalysis converts the security_and_analysis block into the
+// GitHub API representation. Only the settings present in the configuration
+// are sent, the rest are left unchanged.
+func expandSecurityAndAnalysis(model *securityAndAnalysisModel) *github.SecurityAndAnalysis {
+	if model == nil {
+		return nil
+	}
+
+	securityAndAnalysis := &github.SecurityAndAnalysis{}
+
+	if model.AdvancedSecurity != nil {
+		securityAndAnalysis.AdvancedSecurity = &github.AdvancedSecurity{
+			Status: new(model.AdvancedSecurity.Status.ValueString()),
+		}
+	}
+	if model.SecretScanning != nil {
+		securityAndAnalysis.SecretScanning = &github.SecretScanning{
+			Status: new(model.SecretScanning.Status.ValueString()),
+		}
+	}
+	if model.SecretScanningPushProtection != nil {
+		securityAndAnalysis.SecretScanningPushProtection = &github.SecretScanningPushProtection{
+			Status: new(model.SecretScanningPushProtection.Status.ValueString()),
+		}
+	}
+	if model.SecretScanningValidityChecks != nil {
+		securityAndAnalysis.SecretScanningValidityChecks = &github.SecretScanningValidityChecks{
+			Status: new(model.SecretScanningValidityChecks.Status.ValueString()),
+		}
+	}
+	if model.DependabotSecurityUpdates != nil {
+		securityAndAnalysis.DependabotSecurityUpdates = &github.DependabotSecurityUpdates{
+			Status: new(model.DependabotSecurityUpdates.Status.ValueString()),
+		}
+	}
+
+	return securityAndAnalysis
+}
+
+// flattenSecurityAndAnalysis updates the settings present in the
+// security_and_analysis block from the GitHub API response. Settings that are
+// not configured are not managed, and are left out of state to avoid diffs.
+func flattenSecurityAndAnalysis(model *securityAndAnalysisModel, securityAndAnalysis *github.SecurityAndAnalysis) {
+	if model == nil {
+		return
+	}
+
+	if model.AdvancedSecurity != nil {
+		model.AdvancedSecurity.Status = types.StringValue(securityAndAnalysis.GetAdvancedSecurity().GetStatus())
+	}
+	if model.SecretScanning != nil {
+		model.SecretScanning.Status = types.StringValue(securityAndAnalysis.GetSecretScanning().GetStatus())
+	}
+	if model.SecretScanningPushProtection != nil {
+		model.SecretScanningPushProtection.Status = types.StringValue(securityAndAnalysis.GetSecretScanningPushProtection().GetStatus())
+	}
+	if model.SecretScanningValidityChecks != nil {
+		model.SecretScanningValidityChecks.Status = types.StringValue(securityAndAnalysis.GetSecretScanningValidityChecks().GetStatus())
+	}
+	if model.DependabotSecurityUpdates != nil {
+		model.DependabotSecurityUpdates.Status = types.StringValue(securityAndAnalysis.GetDependabotSecurityUpdates().GetStatus())
+	}
+}
+
+// securitySettingChanged reports whether a security setting with its own API
+// endpoint is configured and differs from state. A nil state means the
+// repository is being created.
+func securitySettingChanged(plan types.Bool, state *types.Bool) bool {
+	if plan.IsNull() || plan.IsUnknown() {
+		return false
+	}
+
+	return state == nil || state.ValueBool() != plan.ValueBool()
+}
+
+// updateSecuritySettings applies the security settings that are managed
+// through their own endpoints rather than by editing the repository. Dependabot
+// security updates (automated security fixes) depend on vulnerability alerts,
+// so alerts are enabled before and disabled after changing them.
+func updateSecuritySettings(ctx context.Context, client *github.Client, owner, name string, plan GitHubRepositoryResourceModel, state *GitHubRepositoryResourceModel) error {
+	var stateVulnerabilityAlerts, stateAutomatedSecurityFixes, statePrivateVulnerabilityReporting *types.Bool
+	if state != nil {
+		stateVulnerabilityAlerts = &state.VulnerabilityAlerts
+		stateAutomatedSecurityFixes = &state.AutomatedSecurityFixes
+		statePrivateVulnerabilityReporting = &state.PrivateVulnerabilityReporting
+	}
+
+	vulnerabilityAlertsChanged := securitySettingChanged(plan.VulnerabilityAlerts, stateVulnerabilityAlerts)
+
+	if vulnerabilityAlertsChanged && plan.VulnerabilityAlerts.ValueBool() {
+		if _, err := client.Repositories.EnableVulnerabilityAlerts(ctx, owner, name); err != nil {
+			return fmt.Errorf("unable to enable vulnerability alerts, got error: %w", err)
+		}
+	}
+
+	if securitySettingChanged(plan.AutomatedSecurityFixes, stateAutomatedSecurityFixes) {
+		if plan.AutomatedSecurityFixes.ValueBool() {
+			if _, err := client.Repositories.EnableAutomatedSecurityFixes(ctx, owner, name); err != nil {
+				return fmt.Errorf("unable to enable automated security fixes, got error: %w", err)
+			}
+		} else {
+			if _, err := client.Repositories.DisableAutomatedSecurityFixes(ctx, owner, name); err != nil {
+				return fmt.Errorf("unable to disable automated security fixes, got error: %w", err)
+			}
+		}
+	}
+
+	if vulnerabilityAlertsChanged && !plan.VulnerabilityAlerts.ValueBool() {
+		if _, err := client.Repositories.DisableVulnerabilityAlerts(ctx, owner, name); err != nil {
+			return fmt.Errorf("unable to disable vulnerability alerts, got error: %w", err)
+		}
+	}
+
+	if securitySettingChanged(plan.PrivateVulnerabilityReporting, statePrivateVulnerabilityReporting) {
+		if plan.PrivateVulnerabilityReporting.ValueBool() {
+			if _, err := client.Repositories.EnablePrivateReporting(ctx, owner, name); err != nil {
+				return fmt.Errorf("unable to enable private vulnerability reporting, got error: %w", err)
+			}
+		} else {
+			if _, err := client.Repositories.DisablePrivateReporting(ctx, owner, name); err != nil {
+				return fmt.Errorf("unable to disable private vulnerability reporting, got error: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// readSecuritySettings reads the security settings that are managed through
+// their own endpoints into the model.
+func readSecuritySettings(ctx context.Context, client *github.Client, owner, name string, model *GitHubRepositoryResourceModel) error {
+	err := readSecuritySetting(&model.VulnerabilityAlerts, func() (bool, *github.Response, error) {
+		return client.Repositories.GetVulnerabilityAlerts(ctx, owner, name)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get vulnerability alerts, got error: %w", err)
+	}
+
+	err = readSecuritySetting(&model.AutomatedSecurityFixes, func() (bool, *github.Response, error) {
+		fixes, response, err := client.Repositories.GetAutomatedSecurityFixes(ctx, owner, name)
+		return fixes.GetEnabled(), response, err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get automated security fixes, got error: %w", err)
+	}
+
+	err = readSecuritySetting(&model.PrivateVulnerabilityReporting, func() (bool, *github.Response, error) {
+		return client.Repositories.IsPrivateReportingEnabled(ctx, owner, name)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get private vulnerability reporting, got error: %w", err)
+	}
+
+	return nil
+}
+
+// readSecuritySetting reads a single security setting into setting. Reading
+// these settings needs admin access, so a setting that is neither configured
+// nor known in state is not read and left null, and a setting that cannot be
+// read because access is denied is left unchanged. GitHub answers these
+// endpoints with a 404 when the setting is disabled.
+func readSecuritySetting(setting *types.Bool, read func() (bool, *github.Response, error)) error {
+	if setting.IsNull() || setting.IsUnknown() {
+		*setting = types.BoolNull()
+		return nil
+	}
+
+	enabled, response, err := read()
+	if response != nil && response.StatusCode == http.StatusForbidden {
+		return nil
+	}
+	if response != nil && response.StatusCode == http.StatusNotFound {
+		*setting = types.BoolValue(false)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	*setting = types.BoolValue(enabled)
+
+	return nil
+}
+
+// requiresAdvancedSecurity reports whether the security_and_analysis block
+// enables a feature that needs a GitHub Advanced Security licence on private
+// and internal repositories.
+func requiresAdvancedSecurity(model *securityAndAnalysisModel) bool {
+	if model == nil {
+		return false
+	}
+
+	return (model.AdvancedSecurity != nil && model.AdvancedSecurity.Status.ValueString() == "enabled") ||
+		(model.SecretScanning != nil && model.SecretScanning.Status.ValueString() == "enabled") ||
+		(model.SecretScanningPushProtection != nil && model.SecretScanningPushProtection.Status.ValueString() == "enabled") ||
+		(model.SecretScanningValidityChecks != nil && model.SecretScanningValidityChecks.Status.ValueString() == "enabled")
+}
+
+// advancedSecurityDiagnostic returns an actionable diagnostic when err is the
+// GitHub API rejecting security_and_analysis settings because the owner has no
+// GitHub Advanced Security licence available, or nil for any other error.
+func advancedSecurityDiagnostic(owner string, err error) diag.Diagnostic {
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return nil
+	}
+
+	if errResp.Response.StatusCode != http.StatusForbidden && errResp.Response.StatusCode != http.StatusUnprocessableEntity {
+		return nil
+	}
+
+	message := strings.ToLower(errResp.Message)
+	if !strings.Contains(message, "advanced security") && !strings.Contains(message, "secret scanning") {
+		return nil
+	}
+
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("security_and_analysis"),
+		"GitHub Advanced Security Not Available",
+		fmt.Sprintf("GitHub rejected the security_and_analysis settings: %s\n\n"+
+			"Advanced security and secret scanning on private and internal repositories require a GitHub Advanced Security "+
+			"licence for %q. Purchase or assign a licence, make the repository public, or set the affected settings to \"disabled\".",
+			errResp.Message, owner),
+	)
+}
+
+// localAttributes are boolean arguments that only change the behaviour of the
+// provider and are never sent to the GitHub API.
+var localAttributes = []string{
+	"archive_on_destroy",
+	"deletion_protection",
+	"rename_default_branch",
+}
+
+// repositoryStateUpgrades upgrade the state of a repository from each prior
+// schema version to the next, the schema version is the number of upgrades.
+// Append an upgrade whenever a change to the schema is not compatible with
+// existing state.
+var repositoryStateUpgrades = []stateUpgrade{
+	// 0 to 1: record the defaults of the local attributes, which did not exist
+	// in the first releases.
+	func(state map[string]any) error {
+		defaults := map[string]bool{
+			"archive_on_destroy":    false,
+			"deletion_protection":   true,
+			"rename_default_branch": false,
+		}
+		for attribute, value := range defaults {
+			if state[attribute] == nil {
+				state[attribute] = value
+			}
+		}
+		return nil
+	},
+	// 1 to 2: topics changed from a list to a set, which cannot contain
+	// duplicates.
+	func(state map[string]any) error {
+		topics, ok := state["topics"].([]any)
+		if !ok {
+			return nil
+		}
+		seen := make(map[any]bool, len(topics))
+		unique := make([]any, 0, len(topics))
+		for _, topic := range topics {
+			if !seen[topic] {
+				seen[topic] = true
+				unique = append(unique, topic)
+			}
+		}
+		state["topics"] = unique
+		return nil
+	},
+}
+
+// archiveRepository archives or unarchives a repository. GitHub rejects any
+// other change to an archived repository, so archiving is always applied on
+// its own: unarchive before making changes, archive after making them.
+func archiveRepository(ctx context.Context, client *github.Client, owner, name string, archived bool) (*github.Repository, error) {
+	repo, _, err := client.Repositories.Edit(ctx, owner, name, &github.Repository{
+		Archived: new(archived),
+	})
+
+	return repo, err
+}
+
+// transferRepository transfers a repository to newOwner, giving teamIDs access
+// to it, and waits for GitHub to report the new owner. Transfers to a user
+// only complete once the user has accepted them.
+func transferRepository(ctx context.Context, client *github.Client, id int64, owner, name, newOwner string, teamIDs []int64) (*github.Repository, error) {
+	_, _, err := client.Repositories.Transfer(ctx, owner, name, github.TransferRequest{
+		NewOwner: newOwner,
+		TeamID:   teamIDs,
+	})
+
+	// GitHub responds with 202 Accepted while the transfer is in progress.
+	var accepted *github.AcceptedError
+	if err != nil && !errors.As(err, &accepted) {
+		return nil, fmt.Errorf("unable to transfer the repository to %q, got error: %w", newOwner, err)
+	}
+
+	for {
+		transferred, _, err := client.Repositories.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the repository, got error: %w", err)
+		}
+
+		if strings.EqualFold(transferred.GetOwner().GetLogin(), newOwner) {
+			return transferred, nil
+		}
+
+		if err := sleepWithContext(ctx, repositoryPollInterval); err != nil {
+			return nil, fmt.Errorf("the repository was not transferred to %q before the timeout, transfers to a user must be accepted by the user: %w",
+				newOwner, err)
+		}
+	}
+}
+
+// waitForRepository waits until a repository that is created asynchronously,
+// such as a fork or a repository generated from a template, can be read and its
+// default branch has been populated. It polls until ctx is done.
+func waitForRepository(ctx context.Context, client *github.Client, owner, name string) (*github.Repository, error) {
+	for {
+		repo, response, err := client.Repositories.Get(ctx, owner, name)
+		if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+			return nil, fmt.Errorf("unable to get the repository, got error: %w", err)
+		}
+
+		if err == nil {
+			_, response, err = client.Repositories.GetBranch(ctx, owner, name, repo.GetDefaultBranch(), 0)
+			if err == nil {
+				return repo, nil
+			}
+			if response == nil || response.StatusCode != http.StatusNotFound {
+				return nil, fmt.Errorf("unable to get the default branch, got error: %w", err)
+			}
+		}
+
+		if err := sleepWithContext(ctx, repositoryPollInterval); err != nil {
+			return nil, fmt.Errorf("the repository %s/%s was not ready before the timeout: %w", owner, name, err)
+		}
+	}
+}
+
+// updateDefaultBranch changes the default branch of a repository to branch.
+// When rename is set the current default branch is renamed, otherwise branch
+// must already exist and becomes the default.
+func updateDefaultBranch(ctx context.Context, client *github.Client, owner string, repo *github.Repository, branch string, rename bool) (*github.Repository, error) {
+	if !rename {
+		updated, _, err := client.Repositories.Edit(ctx, owner, repo.GetName(), &github.Repository{
+			DefaultBranch: new(branch),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to set the default branch to %q, got error: %w", branch, err)
+		}
+
+		return updated, nil
+	}
+
+	_, _, err := client.Repositories.RenameBranch(ctx, owner, repo.GetName(), repo.GetDefaultBranch(), branch)
+	if err != nil {
+		return nil, fmt.Errorf("unable to rename the default branch %q to %q, got error: %w", repo.GetDefaultBranch(), branch, err)
+	}
+
+	// Renaming a branch does not return the repository, read it again to
+	// pick up the new default branch.
+	updated, _, err := client.Repositories.Get(ctx, owner, repo.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the repository, got error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// updatePages enables or disables GitHub Pages for a repository. Pages are
+// enabled with GitHub Actions workflows as the build source, since the branch
+// to publish from is not managed by this resource.
+func updatePages(ctx context.Context, client *github.Client, owner string, repo *github.Repository, enabled bool) error {
+	if enabled {
+		_, _, err := client.Repositories.EnablePages(ctx, owner, repo.GetName(), &github.Pages{
+			BuildType: new("workflow"),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to enable GitHub Pages, got error: %w", err)
+		}
+	} else {
+		_, err := client.Repositories.DisablePages(ctx, owner, repo.GetName())
+		if err != nil {
+			return fmt.Errorf("unable to disable GitHub Pages, got error: %w", err)
+		}
+	}
+
+	repo.HasPages = new(enabled)
+
+	return nil
+}
+
+// setPartialState saves the state of a repository that was created or changed
+// but could not be fully configured, so that it is not orphaned. Values that
+// are still unknown are saved as null, and Terraform marks a repository that
+// was being created as tainted.
+func setPartialState(ctx context.Context, state *tfsdk.State, model GitHubRepositoryResourceModel, owner string, repo *github.Repository) diag.Diagnostics {
+	flattenRepository(ctx, &model, repo)
+
+	if model.Owner.IsUnknown() {
+		model.Owner = types.StringValue(owner)
+	}
+
+	diags := state.Set(ctx, &model)
+	if diags.HasError() {
+		return diags
+	}
+
+	raw, err := tftypes.Transform(state.Raw, func(_ *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if !value.IsKnown() {
+			return tftypes.NewValue(value.Type(), nil), nil
+		}
+		return value, nil
+	})
+	if err != nil {
+		diags.AddError(
+			"Unable to Save Repository State",
+			fmt.Sprintf("The state of the repository %s could not be saved, got error: %s", repo.GetFullName(), err),
+		)
+		return diags
+	}
+
+	state.Raw = raw
+
+	return diags
+}
+
+// modifyPlanVisibility reconciles the private and visibility arguments so that
+// changing either one plans a consistent value for the other. Internal
+// repositories are private, so private = true keeps an internal repository
+// internal rather than planning a change to private.
+func modifyPlanVisibility(config GitHubRepositoryResourceModel, plan *GitHubRepositoryResourceModel, stateVisibility types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	switch {
+	case config.Visibility.IsUnknown() || config.Private.IsUnknown():
+		return diags
+	case !config.Visibility.IsNull() && !config.Private.IsNull():
+		if config.Private.ValueBool() != (config.Visibility.ValueString() != "public") {
+			diags.AddAttributeError(
+				path.Root("visibility"),
+				"Conflicting Repository Visibility",
+				fmt.Sprintf("The visibility %q conflicts with private = %t. Internal and private repositories are private, public repositories are not. "+
+					"Remove one of the arguments or make them consistent.",
+					config.Visibility.ValueString(), config.Private.ValueBool()),
+			)
+		}
+	case !config.Visibility.IsNull():
+		plan.Private = types.BoolValue(config.Visibility.ValueString() != "public")
+	case !config.Private.IsNull():
+		switch {
+		case !config.Private.ValueBool():
+			plan.Visibility = types.StringValue("public")
+		case stateVisibility.ValueString() == "internal":
+			plan.Visibility = types.StringValue("internal")
+		default:
+			plan.Visibility = types.StringValue("private")
+		}
+	}
+
+	return diags
+}
+
+// mergeMethod names the arguments of a merge method that creates a commit, and
+// the combinations of commit title and message that GitHub accepts for it.
+type mergeMethod struct {
+	allow          string
+	title          string
+	message        string
+	defaultTitle   string
+	defaultMessage string
+	combinations   map[string][]string
+}
+
+var (
+	squashMergeMethod = mergeMethod{
+		allow:          "allow_squash_merge",
+		title:          "squash_merge_commit_title",
+		message:        "squash_merge_commit_message",
+		defaultTitle:   "COMMIT_OR_PR_TITLE",
+		defaultMessage: "COMMIT_MESSAGES",
+		combinations: map[string][]string{
+			"PR_TITLE":           {"PR_BODY", "COMMIT_MESSAGES", "BLANK"},
+			"COMMIT_OR_PR_TITLE": {"COMMIT_MESSAGES"},
+		},
+	}
+	mergeCommitMethod = mergeMethod{
+		allow:          "allow_merge_commit",
+		title:          "merge_commit_title",
+		message:        "merge_commit_message",
+		defaultTitle:   "MERGE_MESSAGE",
+		defaultMessage: "PR_TITLE",
+		combinations: map[string][]string{
+			"PR_TITLE":      {"PR_BODY", "BLANK"},
+			"MERGE_MESSAGE": {"PR_TITLE"},
+		},
+	}
+)
+
+// validateMergeMethod checks that the commit title and message of a merge
+// method are only set when the method is allowed, and that they are a
+// combination GitHub accepts. A title or message that is not set is checked
+// using its default, and unknown values are checked once they are known.
+func validateMergeMethod(method mergeMethod, allow types.Bool, title, message types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !allow.IsNull() && !allow.IsUnknown() && !allow.ValueBool() {
+		for _, attribute := range []struct {
+			name  string
+			value types.String
+		}{{method.title, title}, {method.message, message}} {
+			if !attribute.value.IsNull() && !attribute.value.IsUnknown() {
+				diags.AddAttributeError(
+					path.Root(attribute.name),
+					"Merge Method Not Allowed",
+					fmt.Sprintf("%s can only be set when %s is true.", attribute.name, method.allow),
+				)
+			}
+		}
+		return diags
+	}
+
+	if title.IsUnknown() || message.IsUnknown() || (title.IsNull() && message.IsNull()) {
+		return diags
+	}
+
+	titleValue, messageValue := title.ValueString(), message.ValueString()
+
+	// The error is reported against the attribute that is set.
+	attribute := method.message
+	switch {
+	case title.IsNull():
+		titleValue = method.defaultTitle
+	case message.IsNull():
+		messageValue = method.defaultMessage
+		attribute = method.title
+	}
+
+	if messages, ok := method.combinations[titleValue]; ok && !slices.Contains(messages, messageValue) {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Merge Commit Message",
+			fmt.Sprintf("%s %q cannot be used with %s %q, it must be one of: %s.",
+				method.message, messageValue, method.title, titleValue, strings.Join(messages, ", ")),
+		)
+	}
+
+	return diags
+}
+
+// flattenRepositoryIdentity maps the identifying fields from a GitHub API
+// repository response into the resource identity.
+func flattenRepositoryIdentity(identity *GitHubRepositoryResourceIdentityModel, repo *github.Repository) {
+	identity.Owner = types.StringValue(repo.GetOwner().GetLogin())
+	identity.Name = types.StringValue(repo.GetName())
+	identity.ID = types.Int64Value(repo.GetID())
 }
 
 // Resource Definition
 
 func (r *GitHubRepositoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_repository"
+	// Repositories can be renamed, so the owner and name in the identity can
+	// change over the lifetime of the resource.
+	resp.ResourceBehavior = resource.ResourceBehavior{
+		MutableIdentity: true,
+	}
+}
+
+func (r *GitHubRepositoryResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"owner": identityschema.StringAttribute{
+				Description:       "The owner of the repository.",
+				RequiredForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the repository.",
+				RequiredForImport: true,
+			},
+			"id": identityschema.Int64Attribute{
+				Description:       "GitHub ID for the repository.",
+				OptionalForImport: true,
+			},
+		},
+	}
 }
 
-func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *GitHubRepositoryResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: int64(len(repositoryStateUpgrades)),
 		Attributes: map[string]schema.Attribute{
 			// Arguments
+			"owner": schema.StringAttribute{
+				Description:         "The organization or user that owns the repository. Defaults to the 'owner' configured in the provider. Repositories can only be created for organizations or the authenticated user. Changing this transfers the repository to the new owner.",
+				MarkdownDescription: "The organization or user that owns the repository. Defaults to the `owner` configured in the provider. Repositories can only be created for organizations or the authenticated user. Changing this transfers the repository to the new owner.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"transfer_team_ids": schema.SetAttribute{
+				ElementType:         types.Int64Type,
+				Description:         "The IDs of teams in the new owner organization that are given access to the repository when it is transferred by changing 'owner'.",
+				MarkdownDescription: "The IDs of teams in the new owner organization that are given access to the repository when it is transferred by changing `owner`.",
+				Optional:            true,
+			},
 			"name": schema.StringAttribute{
-				Description:         "The name of the repository.",
-				MarkdownDescription: "The name of the repository.",
+				Description:         "The name of the repository. Up to 100 letters, numbers, hyphens, underscores and periods.",
+				MarkdownDescription: "The name of the repository. Up to 100 letters, numbers, hyphens, underscores and periods.",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 100),
+					// GitHub replaces any other character with a hyphen.
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[A-Za-z0-9._-]+$`),
+						"must only contain letters, numbers, hyphens, underscores and periods",
+					),
+					stringvalidator.NoneOf(".", ".."),
+				},
 			},
 			"description": schema.StringAttribute{
 				Description:         "The description of the repository.",
@@ -196,6 +1040,18 @@ func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRe
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"visibility": schema.StringAttribute{
+				Description:         "The visibility of the repository. Must be one of 'public', 'private', or 'internal'. Internal repositories are only available to organizations, and are private.",
+				MarkdownDescription: "The visibility of the repository. Must be one of `public`, `private`, or `internal`. Internal repositories are only available to organizations, and are private.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("public", "private", "internal"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"has_issues": schema.BoolAttribute{
 				Description:         "Indicates if the repository has issues enabled.",
 				MarkdownDescription: "Indicates if the repository has issues enabled.",
@@ -229,15 +1085,80 @@ func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRe
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"topics": schema.ListAttribute{
+			"has_downloads": schema.BoolAttribute{
+				Description:         "Indicates if the repository has downloads enabled.",
+				MarkdownDescription: "Indicates if the repository has downloads enabled.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"has_pages": schema.BoolAttribute{
+				Description:         "Indicates if the repository has GitHub Pages enabled. Pages enabled by this resource are built and deployed with GitHub Actions workflows.",
+				MarkdownDescription: "Indicates if the repository has GitHub Pages enabled. Pages enabled by this resource are built and deployed with GitHub Actions workflows.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"allow_forking": schema.BoolAttribute{
+				Description:         "Indicates if forking is allowed for the repository. Organizations can forbid forking private and internal repositories, in which case this cannot be set.",
+				MarkdownDescription: "Indicates if forking is allowed for the repository. Organizations can forbid forking private and internal repositories, in which case this cannot be set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"web_commit_signoff_required": schema.BoolAttribute{
+				Description:         "Indicates if commit signoff is required for web-based commits.",
+				MarkdownDescription: "Indicates if commit signoff is required for web-based commits.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"topics": schema.SetAttribute{
 				ElementType:         types.StringType,
-				Description:         "The list of topics associated with the repository.",
-				MarkdownDescription: "The list of topics associated with the repository.",
+				Description:         "The topics associated with the repository. Topics are only managed when set, an empty set removes all topics. Up to 20 topics of at most 50 lowercase letters, numbers and hyphens, starting with a letter or number.",
+				MarkdownDescription: "The topics associated with the repository. Topics are only managed when set, an empty set removes all topics. Up to 20 topics of at most 50 lowercase letters, numbers and hyphens, starting with a letter or number.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtMost(20),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthBetween(1, 50),
+						stringvalidator.RegexMatches(
+							regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`),
+							"must start with a lowercase letter or number, and only contain lowercase letters, numbers and hyphens",
+						),
+					),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_branch": schema.StringAttribute{
+				Description:         "The default branch of the repository. Changing this sets an existing branch as the default, or renames the current default branch when 'rename_default_branch' is set.",
+				MarkdownDescription: "The default branch of the repository. Changing this sets an existing branch as the default, or renames the current default branch when `rename_default_branch` is set.",
 				Optional:            true,
 				Computed:            true,
-				PlanModifiers: []planmodifier.List{
-					listplanmodifier.UseStateForUnknown(),
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rename_default_branch": schema.BoolAttribute{
+				Description:         "Rename the current default branch when 'default_branch' changes instead of switching to an existing branch. Defaults to 'false'.",
+				MarkdownDescription: "Rename the current default branch when `default_branch` changes instead of switching to an existing branch. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 			"auto_init": schema.BoolAttribute{
 				Description:         "Indicates if the repository is initialized with a README.",
@@ -319,7 +1240,7 @@ func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRe
 	` + "`" + `COMMIT_OR_PR_TITLE` + "`" + ` defaults to the commit's title (if only one commit) or the pull request's title (when more than one commit).`,
 				Optional: true,
 				Computed: true,
-				Default:  stringdefault.StaticString("COMMIT_OR_PR_TITLE"),
+				Default:  stringdefault.StaticString(squashMergeMethod.defaultTitle),
 				Validators: []validator.String{
 					stringvalidator.OneOf("PR_TITLE", "COMMIT_OR_PR_TITLE"),
 				},
@@ -348,7 +1269,7 @@ func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRe
 	` + "`" + `BLANK` + "`" + ` defaults to a blank commit message.`,
 				Optional: true,
 				Computed: true,
-				Default:  stringdefault.StaticString("COMMIT_MESSAGES"),
+				Default:  stringdefault.StaticString(squashMergeMethod.defaultMessage),
 				Validators: []validator.String{
 					stringvalidator.OneOf("PR_BODY", "COMMIT_MESSAGES", "BLANK"),
 				},
@@ -373,7 +1294,7 @@ func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRe
 	` + "`" + `MERGE_MESSAGE` + "`" + ` defaults to the classic title for a merge message (e.g., Merge pull request #123 from branch-name).`,
 				Optional: true,
 				Computed: true,
-				Default:  stringdefault.StaticString("MERGE_MESSAGE"),
+				Default:  stringdefault.StaticString(mergeCommitMethod.defaultTitle),
 				Validators: []validator.String{
 					stringvalidator.OneOf("PR_TITLE", "MERGE_MESSAGE"),
 				},
@@ -402,7 +1323,7 @@ func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRe
 	` + "`" + `BLANK` + "`" + ` defaults to a blank commit message.`,
 				Optional: true,
 				Computed: true,
-				Default:  stringdefault.StaticString("PR_TITLE"),
+				Default:  stringdefault.StaticString(mergeCommitMethod.defaultMessage),
 				Validators: []validator.String{
 					stringvalidator.OneOf("PR_BODY", "PR_TITLE", "BLANK"),
 				},
@@ -410,6 +1331,54 @@ func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRe
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"archived": schema.BoolAttribute{
+				Description:         "Indicates if the repository is archived. Archived repositories are read-only, so any other change requires setting this to 'false' first. Defaults to 'false'.",
+				MarkdownDescription: "Indicates if the repository is archived. Archived repositories are read-only, so any other change requires setting this to `false` first. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"archive_on_destroy": schema.BoolAttribute{
+				Description:         "Archive the repository instead of deleting it when the resource is destroyed. Defaults to 'false'.",
+				MarkdownDescription: "Archive the repository instead of deleting it when the resource is destroyed. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description:         "Prevent the repository from being deleted when the resource is destroyed. Must be set to 'false' and applied before the repository can be deleted. Defaults to 'true'.",
+				MarkdownDescription: "Prevent the repository from being deleted when the resource is destroyed. Must be set to `false` and applied before the repository can be deleted. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"vulnerability_alerts": schema.BoolAttribute{
+				Description:         "Indicates if Dependabot alerts for vulnerable dependencies are enabled. Defaults to the setting of the owner, which is not tracked, when not set.",
+				MarkdownDescription: "Indicates if Dependabot alerts for vulnerable dependencies are enabled. Defaults to the setting of the owner, which is not tracked, when not set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"automated_security_fixes": schema.BoolAttribute{
+				Description:         "Indicates if Dependabot security updates (automated security fixes) are enabled. Requires 'vulnerability_alerts'. Defaults to the setting of the owner, which is not tracked, when not set.",
+				MarkdownDescription: "Indicates if Dependabot security updates (automated security fixes) are enabled. Requires `vulnerability_alerts`. Defaults to the setting of the owner, which is not tracked, when not set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_vulnerability_reporting": schema.BoolAttribute{
+				Description:         "Indicates if private vulnerability reporting is enabled, allowing security researchers to privately report vulnerabilities. Defaults to the setting of the owner, which is not tracked, when not set.",
+				MarkdownDescription: "Indicates if private vulnerability reporting is enabled, allowing security researchers to privately report vulnerabilities. Defaults to the setting of the owner, which is not tracked, when not set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"is_template": schema.BoolAttribute{
 				Description:         "Indicates if the repository is a template repository.",
 				MarkdownDescription: "Indicates if the repository is a template repository.",
@@ -421,11 +1390,15 @@ func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRe
 			},
 			// Template Arguments
 			"template_repository": schema.StringAttribute{
-				Description:         "The name of the template repository to use.",
-				MarkdownDescription: "The name of the template repository to use.",
+				Description:         "The name of the template repository to use. Removing it from the configuration stops tracking the template without replacing the repository.",
+				MarkdownDescription: "The name of the template repository to use. Removing it from the configuration stops tracking the template without replacing the repository.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfNotEqualFold,
+						"Changing the template repository forces a new repository to be created.",
+						"Changing the template repository forces a new repository to be created.",
+					),
 				},
 			},
 			"template_owner": schema.StringAttribute{
@@ -435,7 +1408,26 @@ func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRe
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfNotEqualFold,
+						"Changing the owner of the template repository forces a new repository to be created.",
+						"Changing the owner of the template repository forces a new repository to be created.",
+					),
+				},
+			},
+			"include_all_branches": schema.BoolAttribute{
+				Description:         "Include all branches of the template repository, not only the default branch. Only used when 'template_repository' is set. This is not read from GitHub, so it is not set on imported repositories.",
+				MarkdownDescription: "Include all branches of the template repository, not only the default branch. Only used when `template_repository` is set. This is not read from GitHub, so it is not set on imported repositories.",
+				Optional:            true,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("template_repository")),
+				},
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfKnown,
+						"Changing whether all branches of the template are included forces a new repository to be created.",
+						"Changing whether all branches of the template are included forces a new repository to be created.",
+					),
 				},
 			},
 			// Attributes
@@ -455,12 +1447,172 @@ func (r *GitHubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRe
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"full_name": schema.StringAttribute{
+				Description:         "The full name of the repository, in the form 'owner/name'.",
+				MarkdownDescription: "The full name of the repository, in the form `owner/name`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"html_url": schema.StringAttribute{
+				Description:         "The HTML URL of the repository.",
+				MarkdownDescription: "The HTML URL of the repository.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"clone_url": schema.StringAttribute{
+				Description:         "The URL used for cloning the repository.",
+				MarkdownDescription: "The URL used for cloning the repository.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"git_url": schema.StringAttribute{
+				Description:         "The git URL of the repository.",
+				MarkdownDescription: "The git URL of the repository.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ssh_url": schema.StringAttribute{
+				Description:         "The SSH URL of the repository.",
+				MarkdownDescription: "The SSH URL of the repository.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"svn_url": schema.StringAttribute{
+				Description:         "The SVN URL of the repository.",
+				MarkdownDescription: "The SVN URL of the repository.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description:         "The API URL of the repository.",
+				MarkdownDescription: "The API URL of the repository.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"fork": schema.SingleNestedBlock{
+				Description:         "Create the repository as a fork of another repository. Changing the repository that is forked forces a new repository to be created. The repository that was forked is read from GitHub. Removing the block from the configuration stops tracking the fork without replacing the repository. Whether only the default branch was forked is not read.",
+				MarkdownDescription: "Create the repository as a fork of another repository. Changing the repository that is forked forces a new repository to be created. The repository that was forked is read from GitHub. Removing the block from the configuration stops tracking the fork without replacing the repository. Whether only the default branch was forked is not read.",
+				Attributes: map[string]schema.Attribute{
+					"source_owner": schema.StringAttribute{
+						Description:         "The owner of the repository to fork.",
+						MarkdownDescription: "The owner of the repository to fork.",
+						Required:            true,
+					},
+					"source_repo": schema.StringAttribute{
+						Description:         "The name of the repository to fork.",
+						MarkdownDescription: "The name of the repository to fork.",
+						Required:            true,
+					},
+					"default_branch_only": schema.BoolAttribute{
+						Description:         "Only fork the default branch of the source repository. Defaults to 'false'.",
+						MarkdownDescription: "Only fork the default branch of the source repository. Defaults to `false`.",
+						Optional:            true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRoot("template_repository"),
+						path.MatchRoot("auto_init"),
+						path.MatchRoot("gitignore_template"),
+						path.MatchRoot("license_template"),
+					),
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(
+						forkRequiresReplace,
+						"Changing the repository that is forked forces a new repository to be created.",
+						"Changing the repository that is forked forces a new repository to be created.",
+					),
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Read:              true,
+				Update:            true,
+				Delete:            true,
+				CreateDescription: "The time allowed to create the repository, including waiting for a fork or a repository created from a template to become ready. A string that can be [parsed as a duration](https://pkg.go.dev/time#ParseDuration) such as \"30s\" or \"2h45m\". Defaults to \"10m\".",
+				ReadDescription:   "The time allowed to read the repository. A string that can be [parsed as a duration](https://pkg.go.dev/time#ParseDuration) such as \"30s\" or \"2h45m\". Defaults to \"5m\".",
+				UpdateDescription: "The time allowed to update the repository, including waiting for a transfer to complete. A string that can be [parsed as a duration](https://pkg.go.dev/time#ParseDuration) such as \"30s\" or \"2h45m\". Defaults to \"10m\".",
+				DeleteDescription: "The time allowed to delete or archive the repository. A string that can be [parsed as a duration](https://pkg.go.dev/time#ParseDuration) such as \"30s\" or \"2h45m\". Defaults to \"5m\".",
+			}),
+			"security_and_analysis": schema.SingleNestedBlock{
+				Description:         "Advanced security and secret scanning options. Only the settings present in the configuration are managed. Features other than Dependabot security updates require a GitHub Advanced Security licence on private and internal repositories.",
+				MarkdownDescription: "Advanced security and secret scanning options. Only the settings present in the configuration are managed. Features other than Dependabot security updates require a GitHub Advanced Security licence on private and internal repositories.",
+				Blocks: map[string]schema.Block{
+					"advanced_security":               securityAndAnalysisStatusBlock("advanced security"),
+					"secret_scanning":                 securityAndAnalysisStatusBlock("secret scanning"),
+					"secret_scanning_push_protection": securityAndAnalysisStatusBlock("secret scanning push protection"),
+					"secret_scanning_validity_checks": securityAndAnalysisStatusBlock("secret scanning validity checks"),
+					"dependabot_security_updates":     securityAndAnalysisStatusBlock("dependabot security updates"),
+				},
+			},
 		},
 		Description:         "This resource allows you to create and manage repositories within your GitHub organization or personal account.",
 		MarkdownDescription: "This resource allows you to create and manage repositories within your GitHub organization or personal account.",
 	}
 }
 
+// securityAndAnalysisStatusBlock returns the schema of a security_and_analysis
+// setting, which has a single status of either enabled or disabled.
+func securityAndAnalysisStatusBlock(feature string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description:         fmt.Sprintf("Configure %s on the repository.", feature),
+		MarkdownDescription: fmt.Sprintf("Configure %s on the repository.", feature),
+		Attributes: map[string]schema.Attribute{
+			"status": schema.StringAttribute{
+				Description:         fmt.Sprintf("The state of %s on the repository. Can be 'enabled' or 'disabled'.", feature),
+				MarkdownDescription: fmt.Sprintf("The state of %s on the repository. Can be `enabled` or `disabled`.", feature),
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("enabled", "disabled"),
+				},
+			},
+		},
+	}
+}
+
+func (r *GitHubRepositoryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config GitHubRepositoryResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Merge methods that are not configured default to being allowed.
+	allowed := slices.ContainsFunc([]types.Bool{config.AllowMergeCommit, config.AllowSquashMerge, config.AllowRebaseMerge}, func(allow types.Bool) bool {
+		return allow.IsNull() || allow.IsUnknown() || allow.ValueBool()
+	})
+	if !allowed {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allow_merge_commit"),
+			"No Merge Method Allowed",
+			"At least one of allow_merge_commit, allow_squash_merge or allow_rebase_merge must be true.",
+		)
+	}
+
+	resp.Diagnostics.Append(validateMergeMethod(squashMergeMethod,
+		config.AllowSquashMerge, config.SquashMergeCommitTitle, config.SquashMergeCommitMessage)...)
+	resp.Diagnostics.Append(validateMergeMethod(mergeCommitMethod,
+		config.AllowMergeCommit, config.MergeCommitTitle, config.MergeCommitMessage)...)
+}
+
 func (r *GitHubRepositoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -479,6 +1631,189 @@ func (r *GitHubRepositoryResource) Configure(_ context.Context, req resource.Con
 	r.client = config.Client
 	r.owner = config.Owner
 	r.organization = config.Organization
+	r.readOnly = config.ReadOnly
+	r.owners = config.Owners
+}
+
+// resolveOwner returns the owner of a repository, which is the provider
+// configured owner unless the resource sets its own.
+func (r *GitHubRepositoryResource) resolveOwner(ctx context.Context, owner types.String) (resolvedOwner, error) {
+	if owner.IsNull() || owner.IsUnknown() || strings.EqualFold(owner.ValueString(), r.owner) {
+		return resolvedOwner{Login: r.owner, Organization: r.organization}, nil
+	}
+
+	return r.owners.Resolve(ctx, owner.ValueString())
+}
+
+func (r *GitHubRepositoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to reconcile when the resource is being destroyed.
+	if !req.Plan.Raw.IsNull() {
+		var config GitHubRepositoryResourceModel
+		var plan GitHubRepositoryResourceModel
+		var stateVisibility, stateName, stateOwner types.String
+
+		resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("visibility"), &stateVisibility)...)
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &stateName)...)
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("owner"), &stateOwner)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The owner is only known once the provider has been configured.
+		var owner resolvedOwner
+		ownerKnown := r.client != nil && !plan.Owner.IsUnknown()
+		if r.client != nil && config.Owner.IsNull() {
+			plan.Owner = types.StringValue(r.owner)
+			ownerKnown = true
+		}
+		if ownerKnown {
+			var err error
+			owner, err = r.resolveOwner(ctx, plan.Owner)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("owner"),
+					"Error Communicating with the GitHub API",
+					fmt.Sprintf("Unable to get owner %q, got error: %s", plan.Owner.ValueString(), err),
+				)
+				return
+			}
+		}
+
+		// Logins are case-insensitive, only a different owner transfers the
+		// repository.
+		transferred := !stateOwner.IsNull() && !plan.Owner.IsUnknown() && !strings.EqualFold(plan.Owner.ValueString(), stateOwner.ValueString())
+
+		if transferred || (!stateName.IsNull() && !plan.Name.Equal(stateName)) {
+			unknownNameAttributes(&plan)
+		}
+
+		resp.Diagnostics.Append(modifyPlanVisibility(config, &plan, stateVisibility)...)
+
+		if ownerKnown && owner.Organization == "" && plan.Visibility.ValueString() == "internal" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("visibility"),
+				"Internal Repositories Require an Organization",
+				fmt.Sprintf("The repository cannot be internal because %q is not an organization. Use public or private instead.", owner.Login),
+			)
+		}
+
+		if ownerKnown && owner.Organization != "" {
+			resp.Diagnostics.Append(r.modifyPlanAllowForking(ctx, owner.Organization, config, plan)...)
+		}
+
+		// Only organizations can hold GitHub Advanced Security licences.
+		if ownerKnown && owner.Organization == "" && plan.Visibility.ValueString() == "private" && requiresAdvancedSecurity(plan.SecurityAndAnalysis) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("security_and_analysis"),
+				"GitHub Advanced Security Not Available",
+				fmt.Sprintf("Advanced security and secret scanning require a GitHub Advanced Security licence on private repositories, "+
+					"which is only available to organizations and %q is not an organization. "+
+					"Make the repository public or set the affected settings to \"disabled\".", owner.Login),
+			)
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(r.modifyPlanArchived(ctx, req, resp)...)
+	}
+
+	modifyPlanReadOnly(r.readOnly, req, resp)
+}
+
+// modifyPlanAllowForking warns when allow_forking is enabled on a private or
+// internal repository but the organization does not allow members to fork
+// private repositories, since GitHub rejects the change when it is applied.
+func (r *GitHubRepositoryResource) modifyPlanAllowForking(ctx context.Context, organization string, config, plan GitHubRepositoryResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !config.AllowForking.ValueBool() || plan.Visibility.IsUnknown() || plan.Visibility.ValueString() == "public" {
+		return diags
+	}
+
+	org, err := r.owners.Organization(ctx, organization)
+	if err != nil {
+		// The policy is only used for an early warning, the apply reports the
+		// error from GitHub if forking is not allowed.
+		tflog.Debug(ctx, "Unable to read organization forking policy", map[string]any{
+			"organization": organization,
+			"error":        err.Error(),
+		})
+		return diags
+	}
+
+	if org.MembersCanForkPrivateRepos != nil && !org.GetMembersCanForkPrivateRepos() {
+		diags.AddAttributeWarning(
+			path.Root("allow_forking"),
+			"Forking Not Allowed by Organization",
+			fmt.Sprintf("The organization %q does not allow forking private repositories, so GitHub will reject allow_forking = true "+
+				"for this %s repository. Allow forking in the organization settings, or remove allow_forking from the configuration.",
+				organization, plan.Visibility.ValueString()),
+		)
+	}
+
+	return diags
+}
+
+// modifyPlanArchived fails the plan when a repository stays archived but
+// other settings would change, since GitHub rejects any change to an archived
+// repository.
+func (r *GitHubRepositoryResource) modifyPlanArchived(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var name types.String
+	var stateArchived, planArchived types.Bool
+
+	if req.State.Raw.IsNull() {
+		return diags
+	}
+
+	diags.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("archived"), &stateArchived)...)
+	diags.Append(resp.Plan.GetAttribute(ctx, path.Root("archived"), &planArchived)...)
+	if diags.HasError() || !stateArchived.ValueBool() || !planArchived.ValueBool() {
+		return diags
+	}
+
+	// Changes to arguments that are never sent to GitHub are allowed.
+	plan := tfsdk.State{Schema: req.State.Schema, Raw: resp.Plan.Raw.Copy()}
+	for _, attribute := range localAttributes {
+		var value types.Bool
+		diags.Append(req.State.GetAttribute(ctx, path.Root(attribute), &value)...)
+		diags.Append(plan.SetAttribute(ctx, path.Root(attribute), value)...)
+	}
+
+	// Timeouts and the teams given access on transfer are not repository
+	// settings either.
+	var timeoutsValue timeouts.Value
+	diags.Append(req.State.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("timeouts"), timeoutsValue)...)
+
+	var transferTeamIDs types.Set
+	diags.Append(req.State.GetAttribute(ctx, path.Root("transfer_team_ids"), &transferTeamIDs)...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("transfer_team_ids"), transferTeamIDs)...)
+
+	if diags.HasError() || plan.Raw.Equal(req.State.Raw) {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("archived"),
+		"Repository is Archived",
+		fmt.Sprintf("The repository %q is archived and cannot be changed. Set archived = false to unarchive it before making other changes, "+
+			"or revert the changes to its settings.", name.ValueString()),
+	)
+
+	return diags
 }
 
 // Resource Lifecycle
@@ -494,8 +1829,42 @@ func (r *GitHubRepositoryResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	repo, _, err := client.Repositories.GetByID(ctx, model.ID.ValueInt64())
+	readTimeout, diags := model.Timeouts.Read(ctx, repositoryReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	repo, response, err := client.Repositories.GetByID(ctx, model.ID.ValueInt64())
 	if err != nil {
+		// The repository was deleted outside of Terraform, remove it from
+		// state so that Terraform plans to create it again.
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			resp.Diagnostics.AddWarning(
+				"Repository Not Found",
+				fmt.Sprintf("The repository %q (ID %d) could not be read (%s) and is assumed to have been deleted outside of Terraform. "+
+					"It has been removed from state and will be planned for creation.",
+					model.Name.ValueString(), model.ID.ValueInt64(), response.Status),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		// A repository blocked for legal reasons cannot be managed either.
+		if response != nil && response.StatusCode == http.StatusUnavailableForLegalReasons {
+			resp.Diagnostics.AddWarning(
+				"Repository Unavailable",
+				fmt.Sprintf("The repository %q (ID %d) could not be read because access to it has been blocked for legal reasons (%s). "+
+					"It has been removed from state and will be planned for creation.",
+					model.Name.ValueString(), model.ID.ValueInt64(), response.Status),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Communicating with the GitHub API",
 			fmt.Sprintf("Unable to get repository, got error: %s", err),
@@ -503,7 +1872,51 @@ func (r *GitHubRepositoryResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	// Repositories managed before the owner could be set on the resource are
+	// owned by the provider configured owner.
+	if model.Owner.IsNull() {
+		model.Owner = types.StringValue(r.owner)
+	}
+
+	// The repository ID still resolves after a transfer outside of Terraform.
+	// Keep the repository and record its new owner, so that Terraform plans a
+	// transfer back unless the configuration adopts the new owner.
+	if !strings.EqualFold(repo.GetOwner().GetLogin(), model.Owner.ValueString()) {
+		resp.Diagnostics.AddWarning(
+			"Repository Transferred",
+			fmt.Sprintf("The repository %q (ID %d) is now owned by %q instead of %q. "+
+				"It will be planned for transfer back to %q, set owner to %q to keep it with its new owner.",
+				model.Name.ValueString(), model.ID.ValueInt64(), repo.GetOwner().GetLogin(), model.Owner.ValueString(),
+				model.Owner.ValueString(), repo.GetOwner().GetLogin()),
+		)
+		model.Owner = types.StringValue(repo.GetOwner().GetLogin())
+	}
+
 	flattenRepository(ctx, &model, repo)
+	flattenRepositoryFork(&model, repo)
+	flattenRepositoryTemplate(&model, repo)
+
+	err = readSecuritySettings(ctx, client, repo.GetOwner().GetLogin(), repo.GetName(), &model)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Communicating with the GitHub API", err.Error())
+		return
+	}
+
+	// Imported resources have no value for arguments that are not read from
+	// the GitHub API, use their defaults.
+	if model.ArchiveOnDestroy.IsNull() {
+		model.ArchiveOnDestroy = types.BoolValue(false)
+	}
+	if model.DeletionProtection.IsNull() {
+		model.DeletionProtection = types.BoolValue(true)
+	}
+	if model.RenameDefaultBranch.IsNull() {
+		model.RenameDefaultBranch = types.BoolValue(false)
+	}
+
+	var identity GitHubRepositoryResourceIdentityModel
+	flattenRepositoryIdentity(&identity, repo)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
 
 	// Save updated data into Terraform state.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
@@ -518,14 +1931,100 @@ func (r *GitHubRepositoryResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	createTimeout, diags := model.Timeouts.Create(ctx, repositoryCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	client := r.client
-	owner := r.owner
-	organization := r.organization
+
+	resolved, err := r.resolveOwner(ctx, model.Owner)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Communicating with the GitHub API",
+			fmt.Sprintf("Unable to get owner %q, got error: %s", model.Owner.ValueString(), err),
+		)
+		return
+	}
+
+	owner := resolved.Login
+	organization := resolved.Organization
+
+	// Without an organization the repository is created for the authenticated
+	// user, which must then be the owner.
+	if organization == "" {
+		authenticated, err := r.owners.Resolve(ctx, "")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Communicating with the GitHub API",
+				fmt.Sprintf("Unable to get the authenticated user, got error: %s", err),
+			)
+			return
+		}
+
+		if !strings.EqualFold(authenticated.Login, owner) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("owner"),
+				"Unable to Create Repository",
+				fmt.Sprintf("Repositories can only be created for organizations or the authenticated user %q, but %q is another user.", authenticated.Login, owner),
+			)
+			return
+		}
+	}
 
 	var repo *github.Repository
-	var err error
 
-	if model.TemplateRepository.IsNull() {
+	// Once the repository exists its state is saved even when a later step
+	// fails, otherwise it would be left behind without being tracked.
+	var created *github.Repository
+	defer func() {
+		if created == nil || !resp.Diagnostics.HasError() {
+			return
+		}
+
+		var identity GitHubRepositoryResourceIdentityModel
+		flattenRepositoryIdentity(&identity, created)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+		resp.Diagnostics.Append(setPartialState(ctx, &resp.State, model, owner, created)...)
+	}()
+
+	switch {
+	case model.Fork != nil:
+		// Create a Fork
+		_, _, err = client.Repositories.CreateFork(ctx, model.Fork.SourceOwner.ValueString(), model.Fork.SourceRepo.ValueString(), &github.RepositoryCreateForkOptions{
+			Organization:      organization,
+			Name:              model.Name.ValueString(),
+			DefaultBranchOnly: model.Fork.DefaultBranchOnly.ValueBool(),
+		})
+
+		// GitHub responds with 202 Accepted and creates the fork asynchronously.
+		var accepted *github.AcceptedError
+		if err != nil && !errors.As(err, &accepted) {
+			resp.Diagnostics.AddError("Error Creating Fork", err.Error())
+			return
+		}
+
+		created, err = waitForRepository(ctx, client, owner, model.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Creating Fork", err.Error())
+			return
+		}
+
+		repository := expandRepository(model, expandForUpdate)
+		repo, _, err = client.Repositories.Edit(ctx, owner, model.Name.ValueString(), repository)
+		if err != nil {
+			if d := advancedSecurityDiagnostic(owner, err); d != nil {
+				resp.Diagnostics.Append(d)
+				return
+			}
+			resp.Diagnostics.AddError("Error Applying Settings after Fork Creation", err.Error())
+			return
+		}
+	case model.TemplateRepository.IsNull():
 		// Standard Creation
 		repository := expandRepository(model, expandForCreate)
 		repo, _, err = client.Repositories.Create(ctx, organization, repository)
@@ -533,7 +2032,23 @@ func (r *GitHubRepositoryResource) Create(ctx context.Context, req resource.Crea
 			resp.Diagnostics.AddError("Error Creating Repository", err.Error())
 			return
 		}
-	} else {
+		created = repo
+
+		// Security and analysis settings are applied once the repository exists.
+		if model.SecurityAndAnalysis != nil {
+			repo, _, err = client.Repositories.Edit(ctx, owner, repo.GetName(), &github.Repository{
+				SecurityAndAnalysis: expandSecurityAndAnalysis(model.SecurityAndAnalysis),
+			})
+			if err != nil {
+				if d := advancedSecurityDiagnostic(owner, err); d != nil {
+					resp.Diagnostics.Append(d)
+					return
+				}
+				resp.Diagnostics.AddError("Error Applying Security and Analysis Settings", err.Error())
+				return
+			}
+		}
+	default:
 		// Create from a Template
 		templateRepo := model.TemplateRepository.ValueString()
 		templateOwner := model.TemplateOwner.ValueString()
@@ -549,6 +2064,9 @@ func (r *GitHubRepositoryResource) Create(ctx context.Context, req resource.Crea
 			Description: new(model.Description.ValueString()),
 			Private:     new(model.Private.ValueBool()),
 		}
+		if !model.IncludeAllBranches.IsNull() {
+			templateReq.IncludeAllBranches = new(model.IncludeAllBranches.ValueBool())
+		}
 
 		_, _, err = client.Repositories.CreateFromTemplate(ctx, templateOwner, templateRepo, templateReq)
 		if err != nil {
@@ -556,9 +2074,21 @@ func (r *GitHubRepositoryResource) Create(ctx context.Context, req resource.Crea
 			return
 		}
 
+		// Repositories are generated from a template asynchronously, and editing
+		// one before it is ready fails with a 404.
+		created, err = waitForRepository(ctx, client, owner, model.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Creating Repository from Template", err.Error())
+			return
+		}
+
 		repository := expandRepository(model, expandForUpdate)
 		repo, _, err = client.Repositories.Edit(ctx, owner, model.Name.ValueString(), repository)
 		if err != nil {
+			if d := advancedSecurityDiagnostic(owner, err); d != nil {
+				resp.Diagnostics.Append(d)
+				return
+			}
 			resp.Diagnostics.AddError("Error Applying Settings after Template Creation", err.Error())
 			return
 		}
@@ -580,8 +2110,57 @@ func (r *GitHubRepositoryResource) Create(ctx context.Context, req resource.Crea
 		repo.Topics = returnedTopics
 	}
 
+	// GitHub Pages are managed through their own endpoints.
+	if !model.HasPages.IsNull() && !model.HasPages.IsUnknown() && model.HasPages.ValueBool() != repo.GetHasPages() {
+		err = updatePages(ctx, client, owner, repo, model.HasPages.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Updating GitHub Pages", err.Error())
+			return
+		}
+	}
+
+	err = updateSecuritySettings(ctx, client, owner, repo.GetName(), model, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Security Settings", err.Error())
+		return
+	}
+
+	err = readSecuritySettings(ctx, client, owner, repo.GetName(), &model)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Communicating with the GitHub API", err.Error())
+		return
+	}
+
+	// The default branch can only be changed once the repository exists.
+	if !model.DefaultBranch.IsNull() && !model.DefaultBranch.IsUnknown() && model.DefaultBranch.ValueString() != repo.GetDefaultBranch() {
+		topics := repo.Topics
+		repo, err = updateDefaultBranch(ctx, client, owner, repo, model.DefaultBranch.ValueString(), model.RenameDefaultBranch.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Updating Default Branch", err.Error())
+			return
+		}
+		repo.Topics = topics
+	}
+
+	// Archiving makes the repository read-only, so it is done last.
+	if model.Archived.ValueBool() {
+		repo, err = archiveRepository(ctx, client, owner, repo.GetName(), true)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Archiving Repository", err.Error())
+			return
+		}
+	}
+
 	flattenRepository(ctx, &model, repo)
 
+	if model.Owner.IsUnknown() {
+		model.Owner = types.StringValue(owner)
+	}
+
+	var identity GitHubRepositoryResourceIdentityModel
+	flattenRepositoryIdentity(&identity, repo)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+
 	if model.TemplateRepository.IsNull() {
 		// If not using a template, ensure these are null in state.
 		model.TemplateRepository = types.StringNull()
@@ -603,7 +2182,6 @@ func (r *GitHubRepositoryResource) Update(ctx context.Context, req resource.Upda
 	var state GitHubRepositoryResourceModel
 
 	client := r.client
-	owner := r.owner
 
 	// Read Plan
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
@@ -617,9 +2195,90 @@ func (r *GitHubRepositoryResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	updateTimeout, diags := model.Timeouts.Update(ctx, repositoryUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// The template owner is only unknown for repositories that were not created
+	// from a template, which have none.
+	if model.TemplateOwner.IsUnknown() {
+		model.TemplateOwner = state.TemplateOwner
+	}
+
+	// The repository belongs to the owner in state until it is transferred.
+	owner := r.owner
+	if !state.Owner.IsNull() {
+		owner = state.Owner.ValueString()
+	}
+
+	var repo *github.Repository
+	var err error
+
+	name := state.Name.ValueString()
+
+	// An archived repository that stays archived only has changes to
+	// arguments that are not sent to GitHub (see modifyPlanArchived).
+	if state.Archived.ValueBool() && model.Archived.ValueBool() {
+		repo, _, err = client.Repositories.GetByID(ctx, state.ID.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Communicating with the GitHub API",
+				fmt.Sprintf("Unable to get repository, got error: %s", err),
+			)
+			return
+		}
+
+		flattenRepository(ctx, &model, repo)
+
+		var identity GitHubRepositoryResourceIdentityModel
+		flattenRepositoryIdentity(&identity, repo)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+
+		// Save updated data into Terraform state.
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
+	// GitHub rejects changes to an archived repository, so unarchive it first.
+	if state.Archived.ValueBool() {
+		_, err = archiveRepository(ctx, client, owner, name, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Unarchiving Repository", err.Error())
+			return
+		}
+	}
+
+	// Other changes are made once the repository belongs to the new owner.
+	if !model.Owner.IsUnknown() && !strings.EqualFold(model.Owner.ValueString(), owner) {
+		var teamIDs []int64
+		if !model.TransferTeamIDs.IsNull() && !model.TransferTeamIDs.IsUnknown() {
+			resp.Diagnostics.Append(model.TransferTeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		repo, err = transferRepository(ctx, client, state.ID.ValueInt64(), owner, name, model.Owner.ValueString(), teamIDs)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("owner"), "Error Transferring Repository", err.Error())
+			return
+		}
+
+		owner = repo.GetOwner().GetLogin()
+	}
+
 	repository := expandRepository(model, expandForUpdate)
-	repo, _, err := client.Repositories.Edit(ctx, owner, state.Name.ValueString(), repository)
+	repo, _, err = client.Repositories.Edit(ctx, owner, name, repository)
 	if err != nil {
+		if d := advancedSecurityDiagnostic(owner, err); d != nil {
+			resp.Diagnostics.Append(d)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Communicating with the GitHub API",
 			fmt.Sprintf("Unable to update the repository, got error: %s", err),
@@ -627,6 +2286,30 @@ func (r *GitHubRepositoryResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	// Once the repository has been edited, and possibly renamed, its state is
+	// saved even when a later step fails. The security settings keep their
+	// prior values until they have been applied, so that they are planned
+	// again.
+	updated := repo
+	securitySettingsApplied := false
+	defer func() {
+		if !resp.Diagnostics.HasError() {
+			return
+		}
+
+		partial := model
+		if !securitySettingsApplied {
+			partial.VulnerabilityAlerts = state.VulnerabilityAlerts
+			partial.AutomatedSecurityFixes = state.AutomatedSecurityFixes
+			partial.PrivateVulnerabilityReporting = state.PrivateVulnerabilityReporting
+		}
+
+		var identity GitHubRepositoryResourceIdentityModel
+		flattenRepositoryIdentity(&identity, updated)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+		resp.Diagnostics.Append(setPartialState(ctx, &resp.State, partial, owner, updated)...)
+	}()
+
 	// Topics require a separate API call (PUT /repos/{owner}/{repo}/topics)
 	// and are only set when explicitly specified in the configuration.
 	if !model.Topics.IsNull() && !model.Topics.IsUnknown() {
@@ -643,8 +2326,58 @@ func (r *GitHubRepositoryResource) Update(ctx context.Context, req resource.Upda
 		repo.Topics = returnedTopics
 	}
 
+	// GitHub Pages are managed through their own endpoints.
+	if !model.HasPages.IsNull() && !model.HasPages.IsUnknown() && model.HasPages.ValueBool() != repo.GetHasPages() {
+		err = updatePages(ctx, client, owner, repo, model.HasPages.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Updating GitHub Pages", err.Error())
+			return
+		}
+	}
+
+	err = updateSecuritySettings(ctx, client, owner, repo.GetName(), model, &state)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Security Settings", err.Error())
+		return
+	}
+
+	err = readSecuritySettings(ctx, client, owner, repo.GetName(), &model)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Communicating with the GitHub API", err.Error())
+		return
+	}
+
+	securitySettingsApplied = true
+
+	// The default branch is changed on its own, the branch rename API does not
+	// accept any other repository settings.
+	if !model.DefaultBranch.IsNull() && !model.DefaultBranch.IsUnknown() && model.DefaultBranch.ValueString() != repo.GetDefaultBranch() {
+		topics := repo.Topics
+		repo, err = updateDefaultBranch(ctx, client, owner, repo, model.DefaultBranch.ValueString(), model.RenameDefaultBranch.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Updating Default Branch", err.Error())
+			return
+		}
+		repo.Topics = topics
+	}
+
+	// Archiving makes the repository read-only, so it is done last.
+	if model.Archived.ValueBool() {
+		topics := repo.Topics
+		repo, err = archiveRepository(ctx, client, owner, repo.GetName(), true)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Archiving Repository", err.Error())
+			return
+		}
+		repo.Topics = topics
+	}
+
 	flattenRepository(ctx, &model, repo)
 
+	var identity GitHubRepositoryResourceIdentityModel
+	flattenRepositoryIdentity(&identity, repo)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+
 	// Save updated data into Terraform state.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
@@ -653,7 +2386,6 @@ func (r *GitHubRepositoryResource) Delete(ctx context.Context, req resource.Dele
 	var model GitHubRepositoryResourceModel
 
 	client := r.client
-	owner := r.owner
 
 	// Read Terraform State
 	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
@@ -661,6 +2393,50 @@ func (r *GitHubRepositoryResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, repositoryDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	owner := r.owner
+	if !model.Owner.IsNull() {
+		owner = model.Owner.ValueString()
+	}
+
+	if model.ArchiveOnDestroy.ValueBool() {
+		if !model.Archived.ValueBool() {
+			_, err := archiveRepository(ctx, client, owner, model.Name.ValueString(), true)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Communicating with the GitHub API",
+					fmt.Sprintf("Unable to archive the repository, got error: %s", err),
+				)
+				return
+			}
+		}
+
+		resp.Diagnostics.AddWarning(
+			"Repository Archived Instead of Deleted",
+			fmt.Sprintf("The repository %q has been archived rather than deleted because archive_on_destroy is set. "+
+				"It is no longer managed by Terraform.", model.Name.ValueString()),
+		)
+		return
+	}
+
+	if model.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Repository Deletion Protected",
+			fmt.Sprintf("The repository %q cannot be deleted because deletion_protection is enabled. "+
+				"Set deletion_protection = false and apply the change before destroying the repository, "+
+				"or set archive_on_destroy = true to archive it instead.", model.Name.ValueString()),
+		)
+		return
+	}
+
 	_, err := client.Repositories.Delete(ctx, owner, model.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -675,14 +2451,341 @@ func (r *GitHubRepositoryResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *GitHubRepositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id, err := strconv.ParseInt(req.ID, 10, 64)
-	if err != nil {
+	var id int64
+	var owner, name string
+
+	if req.ID == "" {
+		// Import by identity (Terraform 1.12+).
+		var identity GitHubRepositoryResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		id = identity.ID.ValueInt64()
+		owner, name = identity.Owner.ValueString(), identity.Name.ValueString()
+	} else if parsed, err := strconv.ParseInt(req.ID, 10, 64); err == nil {
+		id = parsed
+	} else {
+		// Otherwise the ID is either `owner/name` or a `name` owned by the
+		// provider configured owner.
+		var found bool
+		owner, name, found = strings.Cut(req.ID, "/")
+		if !found {
+			owner, name = r.owner, req.ID
+		}
+	}
+
+	if id == 0 && (owner == "" || name == "" || strings.Contains(name, "/")) {
 		resp.Diagnostics.AddError(
 			"Error importing item",
-			"Could not import the repository, the ID should be an integer: "+err.Error(),
+			fmt.Sprintf("Could not import the repository, expected a numerical ID, `owner/name`, or `name`, got: %q", owner+"/"+name),
+		)
+		return
+	}
+
+	var repo *github.Repository
+	var err error
+
+	target := owner + "/" + name
+	if id != 0 {
+		target = strconv.FormatInt(id, 10)
+		repo, _, err = r.client.Repositories.GetByID(ctx, id)
+	} else {
+		repo, _, err = r.client.Repositories.Get(ctx, owner, name)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Communicating with the GitHub API",
+			fmt.Sprintf("Unable to get repository %s, got error: %s", target, err),
 		)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repo.GetID())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), repo.GetName())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), repo.GetOwner().GetLogin())...)
+
+	// The fork and the template are only read back once they are tracked in
+	// state.
+	if parent := repo.GetParent(); repo.GetFork() && parent != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fork").AtName("source_owner"), parent.GetOwner().GetLogin())...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fork").AtName("source_repo"), parent.GetName())...)
+	}
+
+	if template := repo.GetTemplateRepository(); template != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("template_repository"), template.GetName())...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("template_owner"), template.GetOwner().GetLogin())...)
+	}
+}
+
+// State Upgrades
+
+func (r *GitHubRepositoryResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(repositoryStateUpgrades...)
+}
+
+// State Moves
+
+// integrationsProviderAddress is the address of the integrations/github
+// provider, without the hostname.
+const integrationsProviderAddress = "integrations/github"
+
+// integrationsRepositoryState is the subset of the state of the
+// integrations/github github_repository resource needed to move it to this
+// provider. Its schema changes between releases, so the raw state is decoded
+// instead of declaring a source schema.
+type integrationsRepositoryState struct {
+	RepoID   int64  `json:"repo_id"`
+	NodeID   string `json:"node_id"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+
+	Description              *string  `json:"description"`
+	HomepageURL              *string  `json:"homepage_url"`
+	Private                  *bool    `json:"private"`
+	Visibility               *string  `json:"visibility"`
+	HasIssues                *bool    `json:"has_issues"`
+	HasProjects              *bool    `json:"has_projects"`
+	HasWiki                  *bool    `json:"has_wiki"`
+	HasDiscussions           *bool    `json:"has_discussions"`
+	HasDownloads             *bool    `json:"has_downloads"`
+	AllowForking             *bool    `json:"allow_forking"`
+	WebCommitSignoffRequired *bool    `json:"web_commit_signoff_required"`
+	Topics                   []string `json:"topics"`
+	DefaultBranch            *string  `json:"default_branch"`
+	AutoInit                 *bool    `json:"auto_init"`
+	GitignoreTemplate        *string  `json:"gitignore_template"`
+	LicenseTemplate          *string  `json:"license_template"`
+	AllowMergeCommit         *bool    `json:"allow_merge_commit"`
+	AllowSquashMerge         *bool    `json:"allow_squash_merge"`
+	AllowRebaseMerge         *bool    `json:"allow_rebase_merge"`
+	AllowAutoMerge           *bool    `json:"allow_auto_merge"`
+	AllowUpdateBranch        *bool    `json:"allow_update_branch"`
+	DeleteBranchOnMerge      *bool    `json:"delete_branch_on_merge"`
+	SquashMergeCommitTitle   *string  `json:"squash_merge_commit_title"`
+	SquashMergeCommitMessage *string  `json:"squash_merge_commit_message"`
+	MergeCommitTitle         *string  `json:"merge_commit_title"`
+	MergeCommitMessage       *string  `json:"merge_commit_message"`
+	IsTemplate               *bool    `json:"is_template"`
+	Archived                 *bool    `json:"archived"`
+	ArchiveOnDestroy         *bool    `json:"archive_on_destroy"`
+	VulnerabilityAlerts      *bool    `json:"vulnerability_alerts"`
+
+	SourceOwner string `json:"source_owner"`
+	SourceRepo  string `json:"source_repo"`
+
+	Template []struct {
+		Owner              string `json:"owner"`
+		Repository         string `json:"repository"`
+		IncludeAllBranches *bool  `json:"include_all_branches"`
+	} `json:"template"`
+
+	SecurityAndAnalysis []struct {
+		AdvancedSecurity             []integrationsStatusState `json:"advanced_security"`
+		SecretScanning               []integrationsStatusState `json:"secret_scanning"`
+		SecretScanningPushProtection []integrationsStatusState `json:"secret_scanning_push_protection"`
+	} `json:"security_and_analysis"`
+
+	HTMLURL      string `json:"html_url"`
+	HTTPCloneURL string `json:"http_clone_url"`
+	GitCloneURL  string `json:"git_clone_url"`
+	SSHCloneURL  string `json:"ssh_clone_url"`
+	SVNURL       string `json:"svn_url"`
+}
+
+type integrationsStatusState struct {
+	Status string `json:"status"`
+}
+
+// optionalString converts a string from the state of a Terraform Plugin SDK
+// resource, which stores unset strings as empty, into a types.String.
+func optionalString(s *string) types.String {
+	if s == nil || *s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(*s)
+}
+
+// optionalBool converts a boolean from the integrations/github provider, which
+// stores unset booleans as false, into a null value for arguments that have no
+// default.
+func optionalBool(b *bool) types.Bool {
+	if b == nil || !*b {
+		return types.BoolNull()
+	}
+	return types.BoolValue(true)
+}
+
+// statusValue returns the status of a security and analysis feature stored as
+// a single element list, or nil when it is not set.
+func statusValue(statuses []integrationsStatusState) *types.String {
+	if len(statuses) == 0 || statuses[0].Status == "" {
+		return nil
+	}
+	return new(types.StringValue(statuses[0].Status))
+}
+
+// moveIntegrationsRepositoryState converts the state of an integrations/github
+// github_repository into the model of this resource. Anything that is read
+// from the GitHub API is refreshed once the state has been moved, the rest is
+// carried over so that the configuration does not need to change.
+func moveIntegrationsRepositoryState(ctx context.Context, source integrationsRepositoryState) (GitHubRepositoryResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	owner, _, found := strings.Cut(source.FullName, "/")
+	if source.RepoID == 0 || source.Name == "" || !found {
+		diags.AddError(
+			"Unable to Move Repository State",
+			fmt.Sprintf("The source state must include repo_id, name and full_name, got: %d, %q and %q.", source.RepoID, source.Name, source.FullName),
+		)
+		return GitHubRepositoryResourceModel{}, diags
+	}
+
+	model := GitHubRepositoryResourceModel{
+		Owner:                    types.StringValue(owner),
+		TransferTeamIDs:          types.SetNull(types.Int64Type),
+		Name:                     types.StringValue(source.Name),
+		Description:              optionalString(source.Description),
+		Homepage:                 optionalString(source.HomepageURL),
+		Private:                  types.BoolPointerValue(source.Private),
+		Visibility:               optionalString(source.Visibility),
+		HasIssues:                types.BoolPointerValue(source.HasIssues),
+		HasProjects:              types.BoolPointerValue(source.HasProjects),
+		HasWiki:                  types.BoolPointerValue(source.HasWiki),
+		HasDiscussions:           types.BoolPointerValue(source.HasDiscussions),
+		HasDownloads:             types.BoolPointerValue(source.HasDownloads),
+		AllowForking:             types.BoolPointerValue(source.AllowForking),
+		WebCommitSignoffRequired: types.BoolPointerValue(source.WebCommitSignoffRequired),
+		Topics:                   types.SetNull(types.StringType),
+		DefaultBranch:            optionalString(source.DefaultBranch),
+		RenameDefaultBranch:      types.BoolValue(false),
+		AutoInit:                 optionalBool(source.AutoInit),
+		GitignoreTemplate:        optionalString(source.GitignoreTemplate),
+		LicenseTemplate:          optionalString(source.LicenseTemplate),
+		AllowMergeCommit:         types.BoolPointerValue(source.AllowMergeCommit),
+		AllowSquashMerge:         types.BoolPointerValue(source.AllowSquashMerge),
+		AllowRebaseMerge:         types.BoolPointerValue(source.AllowRebaseMerge),
+		AllowAutoMerge:           types.BoolPointerValue(source.AllowAutoMerge),
+		AllowUpdateBranch:        types.BoolPointerValue(source.AllowUpdateBranch),
+		DeleteBranchOnMerge:      types.BoolPointerValue(source.DeleteBranchOnMerge),
+		SquashMergeCommitTitle:   optionalString(source.SquashMergeCommitTitle),
+		SquashMergeCommitMessage: optionalString(source.SquashMergeCommitMessage),
+		MergeCommitTitle:         optionalString(source.MergeCommitTitle),
+		MergeCommitMessage:       optionalString(source.MergeCommitMessage),
+		IsTemplate:               types.BoolPointerValue(source.IsTemplate),
+		Archived:                 types.BoolPointerValue(source.Archived),
+		ArchiveOnDestroy:         types.BoolValue(source.ArchiveOnDestroy != nil && *source.ArchiveOnDestroy),
+		// The integrations/github provider has no deletion protection, which
+		// is enabled here as it is for new repositories.
+		DeletionProtection:            types.BoolValue(true),
+		VulnerabilityAlerts:           types.BoolPointerValue(source.VulnerabilityAlerts),
+		AutomatedSecurityFixes:        types.BoolNull(),
+		PrivateVulnerabilityReporting: types.BoolNull(),
+		TemplateRepository:            types.StringNull(),
+		TemplateOwner:                 types.StringNull(),
+		IncludeAllBranches:            types.BoolNull(),
+		ID:                            types.Int64Value(source.RepoID),
+		NodeID:                        types.StringValue(source.NodeID),
+		HasPages:                      types.BoolNull(),
+		FullName:                      types.StringValue(source.FullName),
+		HTMLURL:                       types.StringValue(source.HTMLURL),
+		CloneURL:                      types.StringValue(source.HTTPCloneURL),
+		GitURL:                        types.StringValue(source.GitCloneURL),
+		SSHURL:                        types.StringValue(source.SSHCloneURL),
+		SVNURL:                        types.StringValue(source.SVNURL),
+		URL:                           types.StringNull(),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"read":   types.StringType,
+				"update": types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	}
+
+	if source.Topics != nil {
+		topics, d := types.SetValueFrom(ctx, types.StringType, source.Topics)
+		diags.Append(d...)
+		model.Topics = topics
+	}
+
+	if len(source.Template) > 0 {
+		model.TemplateRepository = types.StringValue(source.Template[0].Repository)
+		model.TemplateOwner = types.StringValue(source.Template[0].Owner)
+		model.IncludeAllBranches = optionalBool(source.Template[0].IncludeAllBranches)
+	}
+
+	if source.SourceOwner != "" && source.SourceRepo != "" {
+		model.Fork = &repositoryForkModel{
+			SourceOwner:       types.StringValue(source.SourceOwner),
+			SourceRepo:        types.StringValue(source.SourceRepo),
+			DefaultBranchOnly: types.BoolNull(),
+		}
+	}
+
+	if len(source.SecurityAndAnalysis) > 0 {
+		settings := source.SecurityAndAnalysis[0]
+		model.SecurityAndAnalysis = &securityAndAnalysisModel{}
+		if status := statusValue(settings.AdvancedSecurity); status != nil {
+			model.SecurityAndAnalysis.AdvancedSecurity = &advancedSecurityModel{Status: *status}
+		}
+		if status := statusValue(settings.SecretScanning); status != nil {
+			model.SecurityAndAnalysis.SecretScanning = &secretScanningModel{Status: *status}
+		}
+		if status := statusValue(settings.SecretScanningPushProtection); status != nil {
+			model.SecurityAndAnalysis.SecretScanningPushProtection = &secretScanningPushProtectionModel{Status: *status}
+		}
+	}
+
+	return model, diags
+}
+
+func (r *GitHubRepositoryResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			// Moves github_repository resources from the integrations/github
+			// provider, which identifies repositories by name rather than ID.
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if req.SourceTypeName != "github_repository" || !strings.HasSuffix(req.SourceProviderAddress, "/"+integrationsProviderAddress) {
+					return
+				}
+
+				if req.SourceRawState == nil {
+					resp.Diagnostics.AddError(
+						"Unable to Move Repository State",
+						"The source state of the repository is missing.",
+					)
+					return
+				}
+
+				var source integrationsRepositoryState
+				if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Move Repository State",
+						fmt.Sprintf("Unable to decode the source state of the repository, got error: %s", err),
+					)
+					return
+				}
+
+				model, diags := moveIntegrationsRepositoryState(ctx, source)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, &model)...)
+
+				if resp.TargetIdentity != nil {
+					identity := GitHubRepositoryResourceIdentityModel{
+						Owner: model.Owner,
+						Name:  model.Name,
+						ID:    model.ID,
+					}
+					resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, identity)...)
+				}
+			},
+		},
+	}
 }
@@ -1,21 +1,39 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/google/go-github/v84/github"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func testAccRepositoryResourceDefaultsConfig(name string) string {
 	return fmt.Sprintf(`
 resource "github_repository" "test" {
-  name = %[1]q
+  name                = %[1]q
+  deletion_protection = false
 }
 `, name)
 }
@@ -49,6 +67,16 @@ func TestAccRepositoryResourceDefaults(t *testing.T) {
 						tfjsonpath.New("private"),
 						knownvalue.Bool(false),
 					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("visibility"),
+						knownvalue.StringExact("public"),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("vulnerability_alerts"),
+						knownvalue.Null(),
+					),
 					statecheck.ExpectKnownValue(
 						"github_repository.test",
 						tfjsonpath.New("has_issues"),
@@ -132,6 +160,7 @@ func TestAccRepositoryResourceDefaults(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"auto_init",
+					"deletion_protection",
 					"gitignore_template",
 					"license_template",
 				},
@@ -150,8 +179,10 @@ func testAccRepositoryResourceAllArgumentsConfig(name string) string {
 	return fmt.Sprintf(`
 resource "github_repository" "test" {
   name                     = %[1]q
+  deletion_protection      = false
 
   allow_auto_merge            = true
+  allow_forking               = true
   allow_merge_commit          = true
   allow_rebase_merge          = true
   allow_squash_merge          = true
@@ -161,6 +192,7 @@ resource "github_repository" "test" {
   description                 = "This is a description."
   gitignore_template          = "Terraform"
   has_discussions             = false
+  has_downloads               = false
   has_issues                  = true
   has_projects                = false
   has_wiki                    = false
@@ -173,6 +205,7 @@ resource "github_repository" "test" {
   merge_commit_message        = "PR_BODY"
   merge_commit_title          = "PR_TITLE"
   topics                      = ["terraform", "testing"]
+  web_commit_signoff_required = true
 
   template_repository = "terraform-module-template"
   template_owner      = "craigsloggett-lab"
@@ -247,7 +280,7 @@ func TestAccRepositoryResourceAllArguments(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"github_repository.test",
 						tfjsonpath.New("topics"),
-						knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.SetExact([]knownvalue.Check{
 							knownvalue.StringExact("terraform"),
 							knownvalue.StringExact("testing"),
 						}),
@@ -307,6 +340,26 @@ func TestAccRepositoryResourceAllArguments(t *testing.T) {
 						tfjsonpath.New("is_template"),
 						knownvalue.Bool(false),
 					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("allow_forking"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("has_downloads"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("web_commit_signoff_required"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("has_pages"),
+						knownvalue.Bool(false),
+					),
 				},
 			},
 			{
@@ -315,10 +368,9 @@ func TestAccRepositoryResourceAllArguments(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"auto_init",
+					"deletion_protection",
 					"gitignore_template",
 					"license_template",
-					"template_repository",
-					"template_owner",
 				},
 			},
 			{
@@ -337,6 +389,7 @@ func testAccRepositoryResourceNoMergeCommitsConfig(name string) string {
 	return fmt.Sprintf(`
 resource "github_repository" "test" {
   name                     = %[1]q
+  deletion_protection      = false
 
   allow_auto_merge            = true
   allow_merge_commit          = false
@@ -432,7 +485,7 @@ func TestAccRepositoryResourceNoMergeCommits(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"github_repository.test",
 						tfjsonpath.New("topics"),
-						knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.SetExact([]knownvalue.Check{
 							knownvalue.StringExact("terraform"),
 						}),
 					),
@@ -489,10 +542,9 @@ func TestAccRepositoryResourceNoMergeCommits(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"auto_init",
+					"deletion_protection",
 					"gitignore_template",
 					"license_template",
-					"template_repository",
-					"template_owner",
 				},
 			},
 			{
@@ -509,6 +561,7 @@ func testAccRepositoryResourceTemplateOwnerDefaultConfig(name string) string {
 	return fmt.Sprintf(`
 resource "github_repository" "test" {
   name                = %[1]q
+  deletion_protection = false
   template_repository = "terraform-module-template"
   template_owner      = "craigsloggett-lab"
 
@@ -550,10 +603,9 @@ func TestAccRepositoryResourceTemplateOwnerDefault(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"auto_init",
+					"deletion_protection",
 					"gitignore_template",
 					"license_template",
-					"template_repository",
-					"template_owner",
 				},
 			},
 			{
@@ -566,6 +618,94 @@ func TestAccRepositoryResourceTemplateOwnerDefault(t *testing.T) {
 	})
 }
 
+func testAccRepositoryResourceTemplateAllBranchesConfig(name string) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                 = %[1]q
+  deletion_protection  = false
+  template_repository  = "terraform-module-template"
+  template_owner       = "craigsloggett-lab"
+  include_all_branches = true
+
+  has_issues = true
+
+  timeouts {
+    create = "15m"
+  }
+}
+`, name)
+}
+
+func TestAccRepositoryResourceTemplateAllBranches(t *testing.T) {
+	repoName := "testing-repo-" + acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceTemplateAllBranchesConfig(repoName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("include_all_branches"),
+						knownvalue.Bool(true),
+					),
+					// Settings are applied once the generated repository is ready.
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("has_issues"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("default_branch"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+			{
+				ResourceName:      "github_repository.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"auto_init",
+					"deletion_protection",
+					"gitignore_template",
+					"include_all_branches",
+					"license_template",
+					"timeouts",
+				},
+			},
+			// Removing the template from the configuration stops tracking it
+			// rather than replacing the repository.
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("github_repository.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("template_repository"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
 // Omitting template_owner should not force replacement on update.
 // Requires GITHUB_OWNER=craigsloggett-lab and a token with access to that
 // organization, since the template_owner defaults to the provider owner and
@@ -575,6 +715,7 @@ func testAccRepositoryResourceTemplateOwnerNoReplaceConfig(name, description str
 	return fmt.Sprintf(`
 resource "github_repository" "test" {
   name                = %[1]q
+  deletion_protection = false
   description         = %[2]q
   template_repository = "terraform-module-template"
 }
@@ -601,3 +742,1478 @@ func TestAccRepositoryResourceTemplateOwnerNoReplace(t *testing.T) {
 		},
 	})
 }
+
+// A read-only provider must refuse to create a repository at plan time.
+
+func TestAccRepositoryResourceReadOnly(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerReadOnlyConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Provider is Read-Only`),
+			},
+		},
+	})
+}
+
+// A repository deleted outside of Terraform should be planned for creation
+// instead of failing the refresh.
+
+func TestAccRepositoryResourceDeletedOutOfBand(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+			},
+			{
+				PreConfig: func() {
+					client, owner := testAccClient(t)
+					if _, err := client.Repositories.Delete(context.Background(), owner, repoName); err != nil {
+						t.Fatalf("unable to delete repository: %s", err)
+					}
+				},
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("github_repository.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+// Repositories can be imported by `owner/name` and by `name` as well as by ID.
+
+func TestAccRepositoryResourceImportByName(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+			},
+			{
+				ResourceName:      "github_repository.test",
+				ImportState:       true,
+				ImportStateId:     repoName,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"auto_init",
+					"deletion_protection",
+					"gitignore_template",
+					"license_template",
+				},
+			},
+			{
+				ResourceName: "github_repository.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					_, owner := testAccClient(t)
+					return owner + "/" + repoName, nil
+				},
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"auto_init",
+					"deletion_protection",
+					"gitignore_template",
+					"license_template",
+				},
+			},
+		},
+	})
+}
+
+// The resource identity tracks the repository through renames and can be used
+// to import it (Terraform 1.12+).
+
+func TestAccRepositoryResourceIdentity(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValue(
+						"github_repository.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact(repoName),
+					),
+					statecheck.ExpectIdentityValueMatchesState(
+						"github_repository.test",
+						tfjsonpath.New("id"),
+					),
+				},
+			},
+			{
+				ResourceName:    "github_repository.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				// Arguments that are not read from the GitHub API are imported
+				// with their defaults.
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName+"-updated"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValue(
+						"github_repository.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact(repoName+"-updated"),
+					),
+				},
+			},
+		},
+	})
+}
+
+// Visibility and private are reconciled with each other.
+
+func testAccRepositoryResourceVisibilityConfig(name, arguments string) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = false
+
+  %[2]s
+}
+`, name, arguments)
+}
+
+func TestAccRepositoryResourceVisibility(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceVisibilityConfig(repoName, `visibility = "private"`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("visibility"),
+						knownvalue.StringExact("private"),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("private"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceVisibilityConfig(repoName, `private = false`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(
+							"github_repository.test",
+							tfjsonpath.New("visibility"),
+							knownvalue.StringExact("public"),
+						),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("visibility"),
+						knownvalue.StringExact("public"),
+					),
+				},
+			},
+			{
+				Config:      providerConfig + testAccRepositoryResourceVisibilityConfig(repoName, "private = false\n  visibility = \"private\""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Conflicting Repository Visibility`),
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceArchivedConfig(name, arguments string) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = false
+  description         = "Archived repository."
+
+  %[2]s
+}
+`, name, arguments)
+}
+
+func TestAccRepositoryResourceArchived(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceArchivedConfig(repoName, "archived = true"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("archived"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			{
+				Config:      providerConfig + testAccRepositoryResourceArchivedConfig(repoName, "archived = true\n  has_wiki = false"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Repository is Archived`),
+			},
+			// Timeouts are not repository settings, so they can be changed.
+			{
+				Config: providerConfig + testAccRepositoryResourceArchivedConfig(repoName, "archived = true\n\n  timeouts {\n    update = \"20m\"\n  }"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("github_repository.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceArchivedConfig(repoName, "archived = false\n  has_wiki = false"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("archived"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("has_wiki"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+		},
+	})
+}
+
+// Destroying a repository with archive_on_destroy set archives it and leaves
+// it in place.
+func TestAccRepositoryResourceArchiveOnDestroy(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			client, owner := testAccClient(t)
+			repo, _, err := client.Repositories.Get(context.Background(), owner, repoName)
+			if err != nil {
+				return fmt.Errorf("expected repository to still exist, got error: %w", err)
+			}
+			if !repo.GetArchived() {
+				return fmt.Errorf("expected repository %s to be archived", repoName)
+			}
+			if _, err := client.Repositories.Delete(context.Background(), owner, repoName); err != nil {
+				return fmt.Errorf("unable to clean up repository: %w", err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceArchivedConfig(repoName, "archive_on_destroy = true"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("archive_on_destroy"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceDeletionProtectionConfig(name string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = %[2]t
+}
+`, name, deletionProtection)
+}
+
+// Repositories are protected from deletion by default and can only be
+// destroyed once deletion_protection = false has been applied.
+func TestAccRepositoryResourceDeletionProtection(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceDeletionProtectionConfig(repoName, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("deletion_protection"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			{
+				Config:      providerConfig + testAccRepositoryResourceDeletionProtectionConfig(repoName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Repository Deletion Protected`),
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceDeletionProtectionConfig(repoName, false),
+			},
+		},
+	})
+}
+
+// testAccRepositoryResourceDefaultBranchConfig leaves the default branch to
+// GitHub when defaultBranch is empty.
+func testAccRepositoryResourceDefaultBranchConfig(name, defaultBranch string, rename bool) string {
+	branch := "null"
+	if defaultBranch != "" {
+		branch = strconv.Quote(defaultBranch)
+	}
+
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                  = %[1]q
+  deletion_protection   = false
+  auto_init             = true
+  default_branch        = %[2]s
+  rename_default_branch = %[3]t
+}
+`, name, branch, rename)
+}
+
+func TestAccRepositoryResourceDefaultBranch(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultBranchConfig(repoName, "", false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("default_branch"),
+						knownvalue.StringExact("main"),
+					),
+				},
+			},
+			// Rename the current default branch.
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultBranchConfig(repoName, "trunk", true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("default_branch"),
+						knownvalue.StringExact("trunk"),
+					),
+				},
+			},
+			// Switch to an existing branch.
+			{
+				PreConfig: func() {
+					client, owner := testAccClient(t)
+					ref, _, err := client.Git.GetRef(context.Background(), owner, repoName, "heads/trunk")
+					if err != nil {
+						t.Fatalf("unable to get branch: %s", err)
+					}
+					_, _, err = client.Git.CreateRef(context.Background(), owner, repoName, github.CreateRef{
+						Ref: "refs/heads/develop",
+						SHA: ref.GetObject().GetSHA(),
+					})
+					if err != nil {
+						t.Fatalf("unable to create branch: %s", err)
+					}
+				},
+				Config: providerConfig + testAccRepositoryResourceDefaultBranchConfig(repoName, "develop", false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("default_branch"),
+						knownvalue.StringExact("develop"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceSecurityAndAnalysisConfig(name, status string) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = false
+  visibility          = "public"
+
+  security_and_analysis {
+    secret_scanning {
+      status = %[2]q
+    }
+    secret_scanning_push_protection {
+      status = %[2]q
+    }
+  }
+}
+`, name, status)
+}
+
+// Secret scanning is available on public repositories without a GitHub
+// Advanced Security licence.
+func TestAccRepositoryResourceSecurityAndAnalysis(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceSecurityAndAnalysisConfig(repoName, "enabled"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("security_and_analysis").AtMapKey("secret_scanning").AtMapKey("status"),
+						knownvalue.StringExact("enabled"),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("security_and_analysis").AtMapKey("secret_scanning_push_protection").AtMapKey("status"),
+						knownvalue.StringExact("enabled"),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("security_and_analysis").AtMapKey("advanced_security"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceSecurityAndAnalysisConfig(repoName, "disabled"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("security_and_analysis").AtMapKey("secret_scanning").AtMapKey("status"),
+						knownvalue.StringExact("disabled"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceSecuritySettingsConfig(name string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                            = %[1]q
+  deletion_protection             = false
+  visibility                      = "public"
+  vulnerability_alerts            = %[2]t
+  automated_security_fixes        = %[2]t
+  private_vulnerability_reporting = %[2]t
+}
+`, name, enabled)
+}
+
+func TestAccRepositoryResourceSecuritySettings(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceSecuritySettingsConfig(repoName, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("vulnerability_alerts"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("automated_security_fixes"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("private_vulnerability_reporting"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// Imported repositories do not read security settings until they
+			// are configured.
+			{
+				ResourceName:      "github_repository.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"automated_security_fixes",
+					"deletion_protection",
+					"private_vulnerability_reporting",
+					"vulnerability_alerts",
+				},
+			},
+			// Vulnerability alerts disabled outside of Terraform are enabled
+			// again.
+			{
+				PreConfig: func() {
+					client, owner := testAccClient(t)
+					if _, err := client.Repositories.DisableVulnerabilityAlerts(context.Background(), owner, repoName); err != nil {
+						t.Fatalf("unable to disable vulnerability alerts: %s", err)
+					}
+				},
+				Config: providerConfig + testAccRepositoryResourceSecuritySettingsConfig(repoName, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("github_repository.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue(
+							"github_repository.test",
+							tfjsonpath.New("vulnerability_alerts"),
+							knownvalue.Bool(true),
+						),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("vulnerability_alerts"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// Automated security fixes are disabled before the vulnerability
+			// alerts they depend on.
+			{
+				Config: providerConfig + testAccRepositoryResourceSecuritySettingsConfig(repoName, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("vulnerability_alerts"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("automated_security_fixes"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("private_vulnerability_reporting"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourcePagesConfig(name string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = false
+  has_pages           = %[2]t
+}
+`, name, enabled)
+}
+
+func TestAccRepositoryResourcePages(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourcePagesConfig(repoName, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("has_pages"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourcePagesConfig(repoName, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("has_pages"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceDescriptionConfig(name, description string) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = false
+  description         = %[2]q
+}
+`, name, description)
+}
+
+// Computed attributes derived from the name are kept from state unless the
+// repository is renamed.
+func TestAccRepositoryResourceComputedAttributes(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("full_name"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^[^/]+/`+repoName+`$`)),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("html_url"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^https://github\.com/[^/]+/`+repoName+`$`)),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("ssh_url"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^git@github\.com:[^/]+/`+repoName+`\.git$`)),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("clone_url"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^https://github\.com/[^/]+/`+repoName+`\.git$`)),
+					),
+				},
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceDescriptionConfig(repoName, "Updated."),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(
+							"github_repository.test",
+							tfjsonpath.New("full_name"),
+							knownvalue.StringRegexp(regexp.MustCompile(`/`+repoName+`$`)),
+						),
+					},
+				},
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceDescriptionConfig(repoName+"-updated", "Updated."),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue(
+							"github_repository.test",
+							tfjsonpath.New("full_name"),
+						),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("full_name"),
+						knownvalue.StringRegexp(regexp.MustCompile(`/`+repoName+`-updated$`)),
+					),
+				},
+			},
+		},
+	})
+}
+
+// Repositories without an owner are owned by the provider configured owner.
+func TestAccRepositoryResourceOwner(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("github_repository.test", "owner", func(value string) error {
+						if _, owner := testAccClient(t); value != owner {
+							return fmt.Errorf("expected owner %q, got: %q", owner, value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceOwnerConfig(name, owner string) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = false
+  owner               = %[2]q
+}
+`, name, owner)
+}
+
+// Transferring a repository needs a second organization that the token can
+// create repositories in, set with GITHUB_TRANSFER_OWNER.
+func TestAccRepositoryResourceTransfer(t *testing.T) {
+	newOwner := os.Getenv("GITHUB_TRANSFER_OWNER")
+	if newOwner == "" {
+		t.Skip("GITHUB_TRANSFER_OWNER must be set to test repository transfers")
+	}
+
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceOwnerConfig(repoName, newOwner),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("github_repository.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("github_repository.test", tfjsonpath.New("full_name")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("full_name"),
+						knownvalue.StringExact(newOwner+"/"+repoName),
+					),
+				},
+			},
+			// A repository transferred outside of Terraform is kept in state
+			// and transferred back.
+			{
+				PreConfig: func() {
+					client, owner := testAccClient(t)
+					_, _, err := client.Repositories.Transfer(context.Background(), newOwner, repoName, github.TransferRequest{NewOwner: owner})
+					var accepted *github.AcceptedError
+					if err != nil && !errors.As(err, &accepted) {
+						t.Fatalf("unable to transfer repository: %s", err)
+					}
+				},
+				Config: providerConfig + testAccRepositoryResourceOwnerConfig(repoName, newOwner),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("github_repository.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("full_name"),
+						knownvalue.StringExact(newOwner+"/"+repoName),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceMergeSettingsConfig(name, settings string) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = false
+
+  %[2]s
+}
+`, name, settings)
+}
+
+func TestAccRepositoryResourceValidateConfig(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + testAccRepositoryResourceDefaultsConfig("testing repository"),
+				ExpectError: regexp.MustCompile(`must only contain letters, numbers, hyphens, underscores and periods`),
+				PlanOnly:    true,
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceMergeSettingsConfig(repoName, `allow_merge_commit = false
+  allow_squash_merge = false
+  allow_rebase_merge = false`),
+				ExpectError: regexp.MustCompile(`No Merge Method Allowed`),
+				PlanOnly:    true,
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceMergeSettingsConfig(repoName, `allow_squash_merge        = false
+  squash_merge_commit_title = "PR_TITLE"`),
+				ExpectError: regexp.MustCompile(`squash_merge_commit_title can only be set when allow_squash_merge is true`),
+				PlanOnly:    true,
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceMergeSettingsConfig(repoName, `merge_commit_title   = "MERGE_MESSAGE"
+  merge_commit_message = "PR_BODY"`),
+				ExpectError: regexp.MustCompile(`Invalid Merge Commit Message`),
+				PlanOnly:    true,
+			},
+			// A title or message that is not set is checked using its default.
+			{
+				Config:      providerConfig + testAccRepositoryResourceMergeSettingsConfig(repoName, `merge_commit_title = "PR_TITLE"`),
+				ExpectError: regexp.MustCompile(`merge_commit_message "PR_TITLE" cannot be used with\s+merge_commit_title\s+"PR_TITLE"`),
+				PlanOnly:    true,
+			},
+			{
+				Config:      providerConfig + testAccRepositoryResourceMergeSettingsConfig(repoName, `squash_merge_commit_message = "PR_BODY"`),
+				ExpectError: regexp.MustCompile(`squash_merge_commit_message "PR_BODY" cannot be used with\s+squash_merge_commit_title\s+"COMMIT_OR_PR_TITLE"`),
+				PlanOnly:    true,
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceTopicsConfig(name string, topics ...string) string {
+	quoted := make([]string, len(topics))
+	for i, topic := range topics {
+		quoted[i] = strconv.Quote(topic)
+	}
+
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = false
+  topics              = [%[2]s]
+}
+`, name, strings.Join(quoted, ", "))
+}
+
+func TestAccRepositoryResourceTopics(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+
+	tooManyTopics := make([]string, 21)
+	for i := range tooManyTopics {
+		tooManyTopics[i] = fmt.Sprintf("topic-%d", i)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + testAccRepositoryResourceTopicsConfig(repoName, "Terraform"),
+				ExpectError: regexp.MustCompile(`must start with a lowercase letter or number`),
+				PlanOnly:    true,
+			},
+			{
+				Config:      providerConfig + testAccRepositoryResourceTopicsConfig(repoName, tooManyTopics...),
+				ExpectError: regexp.MustCompile(`set must contain at most 20 elements`),
+				PlanOnly:    true,
+			},
+			{
+				// GitHub returns topics in its own order.
+				Config: providerConfig + testAccRepositoryResourceTopicsConfig(repoName, "testing", "terraform"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("topics"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("terraform"),
+							knownvalue.StringExact("testing"),
+						}),
+					),
+				},
+			},
+			{
+				// Topics are not managed when they are not set.
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceTopicsConfig(repoName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("topics"),
+						knownvalue.SetSizeExact(0),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceTimeoutsConfig(name, create string) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = false
+
+  timeouts {
+    create = %[2]q
+    read   = "1m"
+    update = "15m"
+    delete = "2m"
+  }
+}
+`, name, create)
+}
+
+func TestAccRepositoryResourceTimeouts(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceTimeoutsConfig(repoName, "5m"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("timeouts").AtMapKey("update"),
+						knownvalue.StringExact("15m"),
+					),
+				},
+			},
+			{
+				Config:      providerConfig + testAccRepositoryResourceTimeoutsConfig(repoName, "invalid"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Time Duration`),
+			},
+		},
+	})
+}
+
+func testAccRepositoryResourceForkConfig(name string) string {
+	return fmt.Sprintf(`
+resource "github_repository" "test" {
+  name                = %[1]q
+  deletion_protection = false
+  description         = "Forked repository."
+  has_issues          = true
+
+  fork {
+    source_owner        = "octocat"
+    source_repo         = "Spoon-Knife"
+    default_branch_only = true
+  }
+}
+`, name)
+}
+
+func TestAccRepositoryResourceFork(t *testing.T) {
+	repoName := "testing-repository-" + acctest.RandString(8)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRepositoryResourceForkConfig(repoName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("description"),
+						knownvalue.StringExact("Forked repository."),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("has_issues"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("default_branch"),
+						knownvalue.StringExact("main"),
+					),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(*terraform.State) error {
+						client, owner := testAccClient(t)
+						repo, _, err := client.Repositories.Get(context.Background(), owner, repoName)
+						if err != nil {
+							return err
+						}
+						if !repo.GetFork() || repo.GetParent().GetFullName() != "octocat/Spoon-Knife" {
+							return fmt.Errorf("expected a fork of octocat/Spoon-Knife, got parent: %q", repo.GetParent().GetFullName())
+						}
+						return nil
+					},
+				),
+			},
+			// The repository that was forked is read from GitHub, but not
+			// whether only its default branch was.
+			{
+				ResourceName:      "github_repository.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"deletion_protection",
+					"fork.default_branch_only",
+				},
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceForkConfig(repoName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Removing the fork block from the configuration stops tracking
+			// the fork rather than replacing the repository.
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("github_repository.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"github_repository.test",
+						tfjsonpath.New("fork"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			{
+				Config: providerConfig + testAccRepositoryResourceDefaultsConfig(repoName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestRepositoryResourceMoveState(t *testing.T) {
+	ctx := context.Background()
+	r := &GitHubRepositoryResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	var identitySchemaResp fwresource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, &identitySchemaResp)
+
+	source := `{
+  "id": "hello-world",
+  "repo_id": 1296269,
+  "node_id": "MDEwOlJlcG9zaXRvcnkxMjk2MjY5",
+  "name": "hello-world",
+  "full_name": "octocat/hello-world",
+  "description": "",
+  "homepage_url": "https://example.com",
+  "visibility": "public",
+  "has_issues": true,
+  "archived": false,
+  "archive_on_destroy": true,
+  "auto_init": false,
+  "topics": ["terraform"],
+  "template": [{"owner": "octocat", "repository": "template", "include_all_branches": false}],
+  "security_and_analysis": [{"advanced_security": [], "secret_scanning": [{"status": "enabled"}]}],
+  "http_clone_url": "https://github.com/octocat/hello-world.git"
+}`
+
+	tests := map[string]struct {
+		providerAddress string
+		typeName        string
+		json            string
+		expectMoved     bool
+		expectError     bool
+	}{
+		"integrations": {
+			providerAddress: "registry.terraform.io/integrations/github",
+			typeName:        "github_repository",
+			json:            source,
+			expectMoved:     true,
+		},
+		"other provider": {
+			providerAddress: "registry.terraform.io/example/github",
+			typeName:        "github_repository",
+			json:            source,
+		},
+		"other resource": {
+			providerAddress: "registry.terraform.io/integrations/github",
+			typeName:        "github_team",
+			json:            source,
+		},
+		"missing repo_id": {
+			providerAddress: "registry.terraform.io/integrations/github",
+			typeName:        "github_repository",
+			json:            `{"name": "hello-world", "full_name": "octocat/hello-world"}`,
+			expectError:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := fwresource.MoveStateRequest{
+				SourceProviderAddress: test.providerAddress,
+				SourceTypeName:        test.typeName,
+				SourceRawState:        &tfprotov6.RawState{JSON: []byte(test.json)},
+			}
+			resp := fwresource.MoveStateResponse{
+				TargetState: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+				TargetIdentity: &tfsdk.ResourceIdentity{
+					Schema: identitySchemaResp.IdentitySchema,
+					Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+				},
+			}
+
+			for _, mover := range r.MoveState(ctx) {
+				mover.StateMover(ctx, req, &resp)
+			}
+
+			if resp.Diagnostics.HasError() != test.expectError {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if resp.TargetState.Raw.IsNull() == test.expectMoved {
+				t.Fatalf("expected the state to be moved: %t", test.expectMoved)
+			}
+
+			if !test.expectMoved {
+				return
+			}
+
+			var model GitHubRepositoryResourceModel
+			if diags := resp.TargetState.Get(ctx, &model); diags.HasError() {
+				t.Fatalf("unable to get the moved state: %v", diags)
+			}
+
+			var topics []string
+			model.Topics.ElementsAs(ctx, &topics, false)
+
+			got := map[string]any{
+				"id":                   model.ID.ValueInt64(),
+				"owner":                model.Owner.ValueString(),
+				"description":          model.Description.IsNull(),
+				"homepage":             model.Homepage.ValueString(),
+				"has_issues":           model.HasIssues.ValueBool(),
+				"archive_on_destroy":   model.ArchiveOnDestroy.ValueBool(),
+				"deletion_protection":  model.DeletionProtection.ValueBool(),
+				"auto_init":            model.AutoInit.IsNull(),
+				"topics":               fmt.Sprint(topics),
+				"template_repository":  model.TemplateRepository.ValueString(),
+				"include_all_branches": model.IncludeAllBranches.IsNull(),
+				"security_and_analysis.advanced_security": model.SecurityAndAnalysis.AdvancedSecurity == nil,
+				"security_and_analysis.secret_scanning":   model.SecurityAndAnalysis.SecretScanning.Status.ValueString(),
+				"clone_url":                               model.CloneURL.ValueString(),
+				"timeouts":                                model.Timeouts.IsNull(),
+			}
+			want := map[string]any{
+				"id":                   int64(1296269),
+				"owner":                "octocat",
+				"description":          true,
+				"homepage":             "https://example.com",
+				"has_issues":           true,
+				"archive_on_destroy":   true,
+				"deletion_protection":  true,
+				"auto_init":            true,
+				"topics":               "[terraform]",
+				"template_repository":  "template",
+				"include_all_branches": true,
+				"security_and_analysis.advanced_security": true,
+				"security_and_analysis.secret_scanning":   "enabled",
+				"clone_url":                               "https://github.com/octocat/hello-world.git",
+				"timeouts":                                true,
+			}
+			for attribute := range want {
+				if got[attribute] != want[attribute] {
+					t.Errorf("expected %s to be %v, got: %v", attribute, want[attribute], got[attribute])
+				}
+			}
+
+			var identity GitHubRepositoryResourceIdentityModel
+			if diags := resp.TargetIdentity.Get(ctx, &identity); diags.HasError() {
+				t.Fatalf("unable to get the moved identity: %v", diags)
+			}
+			if identity.Owner.ValueString() != "octocat" || identity.Name.ValueString() != "hello-world" || identity.ID.ValueInt64() != 1296269 {
+				t.Errorf("unexpected identity: %v", identity)
+			}
+		})
+	}
+}
+
+func TestRepositoryResourceUpgradeState(t *testing.T) {
+	ctx := context.Background()
+	r := &GitHubRepositoryResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	upgraders := r.UpgradeState(ctx)
+	for version := range schemaResp.Schema.Version {
+		if _, ok := upgraders[version]; !ok {
+			t.Fatalf("expected a state upgrader for schema version %d", version)
+		}
+	}
+
+	// State written by the first releases, before the local attributes existed.
+	req := fwresource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{
+  "id": 1296269,
+  "name": "hello-world",
+  "owner": "octocat",
+  "archived": false,
+  "topics": ["terraform", "terraform"]
+}`)},
+	}
+	resp := fwresource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	upgraders[0].StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var model GitHubRepositoryResourceModel
+	if diags := resp.State.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to get the upgraded state: %v", diags)
+	}
+
+	if model.ID.ValueInt64() != 1296269 || model.Name.ValueString() != "hello-world" || model.Owner.ValueString() != "octocat" {
+		t.Errorf("expected the existing attributes to be kept, got: %d, %q and %q", model.ID.ValueInt64(), model.Name.ValueString(), model.Owner.ValueString())
+	}
+
+	if len(model.Topics.Elements()) != 1 {
+		t.Errorf("expected duplicate topics to be removed, got: %s", model.Topics)
+	}
+
+	if model.ArchiveOnDestroy.ValueBool() || !model.DeletionProtection.ValueBool() || model.RenameDefaultBranch.ValueBool() {
+		t.Errorf("expected the local attributes to have their defaults, got: %s, %s and %s",
+			model.ArchiveOnDestroy, model.DeletionProtection, model.RenameDefaultBranch)
+	}
+}
+
+func TestSetPartialState(t *testing.T) {
+	ctx := context.Background()
+	r := &GitHubRepositoryResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	// A plan where every attribute is unknown and every block is absent.
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if _, ok := attributeType.(tftypes.Object); ok {
+			values[name] = tftypes.NewValue(attributeType, nil)
+			continue
+		}
+		values[name] = tftypes.NewValue(attributeType, tftypes.UnknownValue)
+	}
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+
+	var model GitHubRepositoryResourceModel
+	diags := plan.Get(ctx, &model)
+	if diags.HasError() {
+		t.Fatalf("unable to read the plan: %v", diags)
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	diags = setPartialState(ctx, &state, model, "octocat", &github.Repository{
+		ID:       new(int64(1296269)),
+		Name:     new("hello-world"),
+		FullName: new("octocat/hello-world"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !state.Raw.IsFullyKnown() {
+		t.Error("expected every unknown value to be saved as null")
+	}
+
+	var saved GitHubRepositoryResourceModel
+	diags = state.Get(ctx, &saved)
+	if diags.HasError() {
+		t.Fatalf("unable to read the state: %v", diags)
+	}
+
+	if saved.ID.ValueInt64() != 1296269 {
+		t.Errorf("expected the ID of the created repository, got: %d", saved.ID.ValueInt64())
+	}
+
+	if saved.Owner.ValueString() != "octocat" {
+		t.Errorf("expected the owner to be octocat, got: %s", saved.Owner.ValueString())
+	}
+}
+
+func TestReadSecuritySetting(t *testing.T) {
+	response := func(statusCode int) *github.Response {
+		return &github.Response{Response: &http.Response{StatusCode: statusCode}}
+	}
+
+	tests := map[string]struct {
+		setting     types.Bool
+		enabled     bool
+		response    *github.Response
+		err         error
+		expected    types.Bool
+		expectError bool
+	}{
+		"enabled": {
+			setting:  types.BoolValue(false),
+			enabled:  true,
+			response: response(http.StatusOK),
+			expected: types.BoolValue(true),
+		},
+		"disabled": {
+			setting:  types.BoolValue(true),
+			response: response(http.StatusNotFound),
+			err:      errors.New("not found"),
+			expected: types.BoolValue(false),
+		},
+		"forbidden": {
+			setting:  types.BoolValue(true),
+			response: response(http.StatusForbidden),
+			err:      errors.New("forbidden"),
+			expected: types.BoolValue(true),
+		},
+		"error": {
+			setting:     types.BoolValue(true),
+			response:    response(http.StatusInternalServerError),
+			err:         errors.New("internal server error"),
+			expected:    types.BoolValue(true),
+			expectError: true,
+		},
+		"not tracked": {
+			setting:  types.BoolNull(),
+			expected: types.BoolNull(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			setting := test.setting
+			err := readSecuritySetting(&setting, func() (bool, *github.Response, error) {
+				if test.setting.IsNull() {
+					t.Fatal("expected a setting that is not tracked not to be read")
+				}
+				return test.enabled, test.response, test.err
+			})
+			if (err != nil) != test.expectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !setting.Equal(test.expected) {
+				t.Errorf("expected %s, got: %s", test.expected, setting)
+			}
+		})
+	}
+}
+
+func TestExpandRepositoryVisibility(t *testing.T) {
+	tests := map[string]struct {
+		private    types.Bool
+		visibility types.String
+		expected   *github.Repository
+	}{
+		"set": {
+			private:    types.BoolValue(true),
+			visibility: types.StringValue("internal"),
+			expected:   &github.Repository{Private: new(true), Visibility: new("internal")},
+		},
+		"unknown": {
+			private:    types.BoolUnknown(),
+			visibility: types.StringUnknown(),
+			expected:   &github.Repository{},
+		},
+		"null": {
+			private:    types.BoolNull(),
+			visibility: types.StringNull(),
+			expected:   &github.Repository{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := expandRepository(GitHubRepositoryResourceModel{
+				Private:    test.private,
+				Visibility: test.visibility,
+			}, expandForUpdate)
+
+			if repo.GetPrivate() != test.expected.GetPrivate() || (repo.Private == nil) != (test.expected.Private == nil) {
+				t.Errorf("expected private %v, got: %v", test.expected.Private, repo.Private)
+			}
+
+			if repo.GetVisibility() != test.expected.GetVisibility() || (repo.Visibility == nil) != (test.expected.Visibility == nil) {
+				t.Errorf("expected visibility %v, got: %v", test.expected.Visibility, repo.Visibility)
+			}
+		})
+	}
+}
+
+func TestRepositoryResourceReadRemoved(t *testing.T) {
+	ctx := context.Background()
+
+	for name, statusCode := range map[string]int{
+		"not found":   http.StatusNotFound,
+		"unavailable": http.StatusUnavailableForLegalReasons,
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(statusCode)
+				fmt.Fprintf(w, `{"message": %q}`, http.StatusText(statusCode))
+			}))
+			defer server.Close()
+
+			client, err := newGitHubClient(nil, server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := &GitHubRepositoryResource{client: client, owner: "octocat"}
+
+			var schemaResp fwresource.SchemaResponse
+			r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			diags := state.SetAttribute(ctx, path.Root("id"), int64(1296269))
+			diags.Append(state.SetAttribute(ctx, path.Root("name"), "hello-world")...)
+			if diags.HasError() {
+				t.Fatalf("unable to set the state: %v", diags)
+			}
+
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if resp.Diagnostics.WarningsCount() != 1 {
+				t.Errorf("expected a warning, got: %v", resp.Diagnostics)
+			}
+			if !resp.State.Raw.IsNull() {
+				t.Error("expected the repository to be removed from state")
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// stateUpgrade upgrades the raw state of a resource from one schema version to
+// the next, modifying it in place.
+type stateUpgrade func(state map[string]any) error
+
+// stateUpgraders returns the state upgraders for a resource where upgrades[i]
+// upgrades its state from schema version i to i+1, making the current schema
+// version len(upgrades).
+//
+// Terraform upgrades state to the current version in a single call, so the
+// upgrader for each prior version applies every later upgrade in turn. Working
+// on the raw state means that prior schemas never have to be kept around, and
+// each change to a schema only needs the upgrade from the version before it.
+func stateUpgraders(upgrades ...stateUpgrade) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(upgrades))
+
+	for version := range upgrades {
+		upgraders[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				state, err := upgradeRawState(req.RawState, upgrades[version:])
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Unable to upgrade the state from schema version %d, got error: %s", version, err),
+					)
+					return
+				}
+
+				// Attributes that have been removed from the schema are dropped.
+				resp.State.Raw, err = state.UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+					ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+						IgnoreUndefinedAttributes: true,
+					},
+				})
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Unable to decode the upgraded state from schema version %d, got error: %s", version, err),
+					)
+				}
+			},
+		}
+	}
+
+	return upgraders
+}
+
+// upgradeRawState applies upgrades to the raw state in order.
+func upgradeRawState(raw *tfprotov6.RawState, upgrades []stateUpgrade) (*tfprotov6.RawState, error) {
+	if raw == nil || raw.JSON == nil {
+		return nil, fmt.Errorf("the state has no JSON representation")
+	}
+
+	// Numbers are kept as written so that large IDs do not lose precision.
+	decoder := json.NewDecoder(bytes.NewReader(raw.JSON))
+	decoder.UseNumber()
+
+	var state map[string]any
+	if err := decoder.Decode(&state); err != nil {
+		return nil, err
+	}
+
+	for _, upgrade := range upgrades {
+		if err := upgrade(state); err != nil {
+			return nil, err
+		}
+	}
+
+	upgraded, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.RawState{JSON: upgraded}, nil
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestStateUpgraders(t *testing.T) {
+	ctx := context.Background()
+
+	// Version 2 of the schema, where count was renamed to total and then
+	// doubled.
+	current := schema.Schema{
+		Version: 2,
+		Attributes: map[string]schema.Attribute{
+			"id":    schema.Int64Attribute{Computed: true},
+			"total": schema.Int64Attribute{Optional: true},
+		},
+	}
+
+	upgraders := stateUpgraders(
+		func(state map[string]any) error {
+			state["total"] = state["count"]
+			delete(state, "count")
+			return nil
+		},
+		func(state map[string]any) error {
+			total, err := state["total"].(json.Number).Int64()
+			state["total"] = total * 2
+			return err
+		},
+	)
+
+	if len(upgraders) != 2 {
+		t.Fatalf("expected an upgrader for each prior version, got: %d", len(upgraders))
+	}
+
+	tests := map[int64]struct {
+		state string
+		total int64
+	}{
+		0: {state: `{"id": 9007199254740993, "count": 1, "removed": "value"}`, total: 2},
+		1: {state: `{"id": 9007199254740993, "total": 1}`, total: 2},
+	}
+
+	for version, test := range tests {
+		t.Run(fmt.Sprintf("version %d", version), func(t *testing.T) {
+			req := resource.UpgradeStateRequest{
+				RawState: &tfprotov6.RawState{JSON: []byte(test.state)},
+			}
+			resp := resource.UpgradeStateResponse{
+				State: tfsdk.State{Schema: current},
+			}
+
+			upgraders[version].StateUpgrader(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var id, total types.Int64
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("total"), &total)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unable to read the upgraded state: %v", resp.Diagnostics)
+			}
+
+			if id.ValueInt64() != 9007199254740993 {
+				t.Errorf("expected the id to keep its precision, got: %d", id.ValueInt64())
+			}
+
+			if total.ValueInt64() != test.total {
+				t.Errorf("expected total to be %d, got: %d", test.total, total.ValueInt64())
+			}
+		})
+	}
+}
+
+func TestStateUpgradersError(t *testing.T) {
+	ctx := context.Background()
+
+	upgraders := stateUpgraders(func(state map[string]any) error {
+		return fmt.Errorf("unsupported state")
+	})
+
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schema.Schema{Version: 1}},
+	}
+	upgraders[0].StateUpgrader(ctx, resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{}`)},
+	}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error diagnostic")
+	}
+}
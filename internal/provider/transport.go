@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries = 3
+
+	// The first retry waits around retryBaseDelay, doubling on every attempt
+	// up to retryMaxDelay.
+	retryBaseDelay = 1 * time.Second
+	retryMaxDelay  = 30 * time.Second
+
+	// GitHub recommends waiting at least one minute before retrying a request
+	// that hit a secondary rate limit without a Retry-After header.
+	secondaryRateLimitMinDelay = 1 * time.Minute
+
+	// Wait slightly past the primary rate limit reset to allow for clock drift.
+	primaryRateLimitResetBuffer = 1 * time.Second
+)
+
+// defaultRetryableErrors are the HTTP status codes retried when the provider
+// configuration does not set retryable_errors.
+var defaultRetryableErrors = []int64{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// idempotentMethods are the HTTP methods that are retried after failing with
+// one of the retryable status codes. A failed request may still have been
+// applied by GitHub, so repeating any other request could, for example,
+// create a resource twice.
+var idempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// retryTransport retries requests that hit a GitHub API rate limit or, for
+// idempotent methods, failed with one of the configured retryable status
+// codes. Rate limited requests wait for the time GitHub asks for (Retry-After
+// or X-RateLimit-Reset), other failures back off exponentially with jitter.
+type retryTransport struct {
+	base            http.RoundTripper
+	maxRetries      int
+	retryableErrors map[int]bool
+
+	// sleep waits for the given duration or until the context is done. It is
+	// replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// newRetryTransport returns a transport sending requests through base, making
+// at most maxRetries additional attempts for each request.
+func newRetryTransport(base http.RoundTripper, maxRetries int, retryableErrors []int64) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	statuses := make(map[int]bool, len(retryableErrors))
+	for _, status := range retryableErrors {
+		statuses[int(status)] = true
+	}
+
+	return &retryTransport{
+		base:            base,
+		maxRetries:      maxRetries,
+		retryableErrors: statuses,
+		sleep:           sleepWithContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		// The request body has already been consumed by a previous attempt.
+		if attempt > 0 && req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		// Requests whose body cannot be replayed are never retried.
+		if attempt >= t.maxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		delay, reason, retry := t.retryDelay(req, resp, attempt)
+		if !retry {
+			return resp, nil
+		}
+
+		// Waiting beyond the deadline of the request would only replace this
+		// response with a less descriptive context error.
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, nil
+		}
+
+		// Release the connection before waiting.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		tflog.Warn(ctx, "Retrying GitHub API request", map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"status":  resp.StatusCode,
+			"reason":  reason,
+			"delay":   delay.String(),
+			"attempt": attempt + 1,
+		})
+
+		if err := t.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay determines whether a response should be retried and how long to
+// wait before doing so.
+func (t *retryTransport) retryDelay(req *http.Request, resp *http.Response, attempt int) (time.Duration, string, bool) {
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		// Secondary rate limits tell us exactly how long to wait.
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil {
+				return time.Duration(seconds) * time.Second, "secondary rate limit", true
+			}
+		}
+
+		// Primary rate limits reset at a fixed point in time.
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				return max(time.Until(time.Unix(reset, 0))+primaryRateLimitResetBuffer, 0), "primary rate limit", true
+			}
+		}
+
+		// Secondary rate limits (previously abuse detection) are sometimes only
+		// identified by the response body.
+		if isSecondaryRateLimit(resp) {
+			return max(backoff(attempt), secondaryRateLimitMinDelay), "secondary rate limit", true
+		}
+
+		return 0, "", false
+	}
+
+	if t.retryableErrors[resp.StatusCode] && slices.Contains(idempotentMethods, req.Method) {
+		return backoff(attempt), "retryable error", true
+	}
+
+	return 0, "", false
+}
+
+// isSecondaryRateLimit reports whether the response body describes a
+// secondary rate limit. The body is restored so it can still be read by the
+// caller.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	message := strings.ToLower(string(body))
+
+	return strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse detection")
+}
+
+// backoff returns an exponentially increasing delay with jitter for the given
+// attempt, between half and all of the exponential delay.
+func backoff(attempt int) time.Duration {
+	delay := retryMaxDelay
+	if attempt < 16 {
+		delay = min(retryBaseDelay<<attempt, retryMaxDelay)
+	}
+
+	return delay/2 + rand.N(delay/2+1) //nolint:gosec // Jitter does not need a cryptographic source.
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// testRetryTransport returns a retry transport replying with the given
+// responses in order, recording the requested bodies and delays.
+func testRetryTransport(maxRetries int, responses ...*http.Response) (*retryTransport, *[]string, *[]time.Duration) {
+	var bodies []string
+	var delays []time.Duration
+
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body := ""
+		if req.Body != nil {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+		}
+		bodies = append(bodies, body)
+		resp := responses[0]
+		responses = responses[1:]
+		return resp, nil
+	})
+
+	transport := newRetryTransport(base, maxRetries, defaultRetryableErrors)
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	return transport, &bodies, &delays
+}
+
+func testResponse(status int, header map[string]string, body string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	for k, v := range header {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
+
+func TestRetryTransportRetryableErrors(t *testing.T) {
+	transport, bodies, delays := testRetryTransport(3,
+		testResponse(http.StatusBadGateway, nil, ""),
+		testResponse(http.StatusServiceUnavailable, nil, ""),
+		testResponse(http.StatusOK, nil, "ok"),
+	)
+
+	req, _ := http.NewRequest(http.MethodPut, "https://api.github.com/repos/octocat/hello-world/topics", strings.NewReader(`{"names":["terraform"]}`))
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected a successful response, got: %d", resp.StatusCode)
+	}
+
+	if len(*bodies) != 3 {
+		t.Fatalf("expected 3 attempts, got: %d", len(*bodies))
+	}
+
+	for _, body := range *bodies {
+		if body != `{"names":["terraform"]}` {
+			t.Errorf("expected the request body to be replayed, got: %q", body)
+		}
+	}
+
+	for i, delay := range *delays {
+		if limit := retryBaseDelay << i; delay < limit/2 || delay > limit {
+			t.Errorf("expected attempt %d to back off between %s and %s, got: %s", i+1, limit/2, limit, delay)
+		}
+	}
+}
+
+func TestRetryTransportMaxRetries(t *testing.T) {
+	transport, bodies, _ := testRetryTransport(1,
+		testResponse(http.StatusInternalServerError, nil, ""),
+		testResponse(http.StatusInternalServerError, nil, ""),
+	)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected the last failed response, got: %d", resp.StatusCode)
+	}
+
+	if len(*bodies) != 2 {
+		t.Fatalf("expected 2 attempts, got: %d", len(*bodies))
+	}
+}
+
+func TestRetryTransportRateLimits(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second)
+
+	transport, bodies, delays := testRetryTransport(3,
+		testResponse(http.StatusForbidden, map[string]string{"Retry-After": "10"}, ""),
+		testResponse(http.StatusForbidden, map[string]string{
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+		}, ""),
+		testResponse(http.StatusForbidden, nil, `{"message": "You have exceeded a secondary rate limit."}`),
+		testResponse(http.StatusOK, nil, "ok"),
+	)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if len(*bodies) != 4 {
+		t.Fatalf("expected 4 attempts, got: %d", len(*bodies))
+	}
+
+	if (*delays)[0] != 10*time.Second {
+		t.Errorf("expected to wait for Retry-After, got: %s", (*delays)[0])
+	}
+
+	if (*delays)[1] < 28*time.Second || (*delays)[1] > 32*time.Second {
+		t.Errorf("expected to wait until the rate limit reset, got: %s", (*delays)[1])
+	}
+
+	if (*delays)[2] < secondaryRateLimitMinDelay {
+		t.Errorf("expected to wait at least %s for a secondary rate limit, got: %s", secondaryRateLimitMinDelay, (*delays)[2])
+	}
+}
+
+func TestRetryTransportNotRetried(t *testing.T) {
+	transport, bodies, _ := testRetryTransport(3,
+		testResponse(http.StatusForbidden, nil, `{"message": "Resource not accessible by integration"}`),
+	)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "Resource not accessible") {
+		t.Errorf("expected the response body to be preserved, got: %q", body)
+	}
+
+	if len(*bodies) != 1 {
+		t.Fatalf("expected 1 attempt, got: %d", len(*bodies))
+	}
+}
+
+// Requests that are not idempotent may have been applied before failing.
+func TestRetryTransportNotIdempotent(t *testing.T) {
+	for _, method := range []string{http.MethodPost, http.MethodPatch} {
+		t.Run(method, func(t *testing.T) {
+			transport, bodies, _ := testRetryTransport(3,
+				testResponse(http.StatusBadGateway, nil, ""),
+			)
+
+			req, _ := http.NewRequest(method, "https://api.github.com/orgs/octocat/repos", strings.NewReader(`{"name":"hello-world"}`))
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusBadGateway {
+				t.Fatalf("expected the failed response, got: %d", resp.StatusCode)
+			}
+
+			if len(*bodies) != 1 {
+				t.Fatalf("expected 1 attempt, got: %d", len(*bodies))
+			}
+		})
+	}
+}
+
+func TestRetryTransportDeadline(t *testing.T) {
+	transport, bodies, delays := testRetryTransport(3,
+		testResponse(http.StatusForbidden, map[string]string{"Retry-After": "60"}, `{"message": "You have exceeded a secondary rate limit"}`),
+		testResponse(http.StatusOK, nil, "ok"),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected the rate limited response, got: %d", resp.StatusCode)
+	}
+
+	if len(*bodies) != 1 || len(*delays) != 0 {
+		t.Fatalf("expected no retry past the deadline, got %d attempts and %d delays", len(*bodies), len(*delays))
+	}
+}